@@ -2,13 +2,17 @@ package gdal
 
 /*
 #include "go_gdal.h"
+#include "go_gdal_dataset.h"
 #include "gdal_version.h"
 */
 import "C"
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"runtime"
+	"strings"
 	"unsafe"
 )
 
@@ -516,6 +520,119 @@ func CPLGetConfigOption(key, val string) string {
 	return C.GoString(C.CPLGetConfigOption(cKey, cVal))
 }
 
+// CPLSetThreadLocalConfigOption sets a configuration option for the
+// calling OS thread only.  Goroutines may migrate between OS threads, so
+// the caller should hold runtime.LockOSThread while the option is in use.
+func CPLSetThreadLocalConfigOption(key, val string) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cVal := C.CString(val)
+	defer C.free(unsafe.Pointer(cVal))
+	C.CPLSetThreadLocalConfigOption(cKey, cVal)
+}
+
+// CPLGetThreadLocalConfigOption fetches a configuration option set for the
+// calling OS thread, or the default value if it is not set
+func CPLGetThreadLocalConfigOption(key, val string) string {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	cVal := C.CString(val)
+	defer C.free(unsafe.Pointer(cVal))
+	return C.GoString(C.CPLGetThreadLocalConfigOption(cKey, cVal))
+}
+
+// CPLGetConfigOptions fetches all the process-global configuration options
+func CPLGetConfigOptions() map[string]string {
+	p := C.CPLGetConfigOptions()
+	defer C.CSLDestroy(p)
+	return cslToConfigOptions(p)
+}
+
+// CPLSetConfigOptions replaces all the process-global configuration options
+func CPLSetConfigOptions(options map[string]string) {
+	opts, free := configOptionsToCSL(options)
+	defer free()
+	C.CPLSetConfigOptions(opts)
+}
+
+// CPLGetThreadLocalConfigOptions fetches all the configuration options set
+// for the calling OS thread
+func CPLGetThreadLocalConfigOptions() map[string]string {
+	p := C.CPLGetThreadLocalConfigOptions()
+	defer C.CSLDestroy(p)
+	return cslToConfigOptions(p)
+}
+
+// CPLSetThreadLocalConfigOptions replaces all the configuration options set
+// for the calling OS thread
+func CPLSetThreadLocalConfigOptions(options map[string]string) {
+	opts, free := configOptionsToCSL(options)
+	defer free()
+	C.CPLSetThreadLocalConfigOptions(opts)
+}
+
+// WithConfig runs fn with the given configuration options applied to the
+// calling goroutine only.  The goroutine is locked to its OS thread, the
+// options are set as thread-local options, and the previous thread-local
+// options are restored once fn returns or panics.
+func WithConfig(options map[string]string, fn func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	saved := C.CPLGetThreadLocalConfigOptions()
+	defer func() {
+		C.CPLSetThreadLocalConfigOptions(saved)
+		C.CSLDestroy(saved)
+	}()
+
+	for key, val := range options {
+		CPLSetThreadLocalConfigOption(key, val)
+	}
+	return fn()
+}
+
+// Convert a NULL terminated list of KEY=VALUE strings to a map
+func cslToConfigOptions(p **C.char) map[string]string {
+	options := make(map[string]string)
+	if p == nil {
+		return options
+	}
+	q := uintptr(unsafe.Pointer(p))
+	for {
+		p = (**C.char)(unsafe.Pointer(q))
+		if *p == nil {
+			break
+		}
+		kv := strings.SplitN(C.GoString(*p), "=", 2)
+		if len(kv) == 2 {
+			options[kv[0]] = kv[1]
+		}
+		q += unsafe.Sizeof(q)
+	}
+	return options
+}
+
+// Convert a map to a NULL terminated list of KEY=VALUE strings.  The
+// returned function frees the list.
+func configOptionsToCSL(options map[string]string) (**C.char, func()) {
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	i := 0
+	for key, val := range options {
+		opts[i] = C.CString(key + "=" + val)
+		i++
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	free := func() {
+		for i := 0; i < length; i++ {
+			C.free(unsafe.Pointer(opts[i]))
+		}
+	}
+	return (**C.char)(unsafe.Pointer(&opts[0])), free
+}
+
 /* ==================================================================== */
 /*      Registration/driver related.                                    */
 /* ==================================================================== */
@@ -699,8 +816,6 @@ func OpenShared(filename string, access Access) Dataset {
 	return Dataset{dataset}
 }
 
-// Unimplemented: DumpOpenDatasets
-
 // Return the driver by short name
 func GetDriverByName(driverName string) (Driver, error) {
 	cName := C.CString(driverName)
@@ -1248,7 +1363,85 @@ func (dataset Dataset) BuildOverviews(
 	return CPLErrContainer{ErrVal: cErr}.Err()
 }
 
-// Unimplemented: GDALGetOpenDatasets
+// Fetch the datasets currently open in GDAL
+func OpenDatasets() []Dataset {
+	var cDatasets *C.GDALDatasetH
+	var count C.int
+	C.GDALGetOpenDatasets(&cDatasets, &count)
+
+	datasets := make([]Dataset, int(count))
+	q := uintptr(unsafe.Pointer(cDatasets))
+	for i := range datasets {
+		datasets[i] = Dataset{*(*C.GDALDatasetH)(unsafe.Pointer(q))}
+		q += unsafe.Sizeof(*cDatasets)
+	}
+	return datasets
+}
+
+// Fetch the current reference count of the dataset
+func (dataset Dataset) ReferenceCount() int {
+	return int(C.go_GDALDatasetGetRefCount(dataset.cval))
+}
+
+// Fetch whether the dataset was opened in shared mode
+func (dataset Dataset) IsShared() bool {
+	return C.go_GDALDatasetIsShared(dataset.cval) != 0
+}
+
+// Description of an open dataset, as reported by OpenDatasetsInfo
+type OpenDatasetInfo struct {
+	Dataset        Dataset
+	Description    string
+	Driver         string
+	ReferenceCount int
+	Shared         bool
+	Access         Access
+}
+
+// Describe the datasets currently open in GDAL
+func OpenDatasetsInfo() []OpenDatasetInfo {
+	datasets := OpenDatasets()
+	infos := make([]OpenDatasetInfo, len(datasets))
+	for i, dataset := range datasets {
+		desc := C.GoString(C.GDALGetDescription(C.GDALMajorObjectH(unsafe.Pointer(dataset.cval))))
+		var driver string
+		if cDriver := C.GDALGetDatasetDriver(dataset.cval); cDriver != nil {
+			driver = C.GoString(C.GDALGetDriverShortName(cDriver))
+		}
+		infos[i] = OpenDatasetInfo{
+			Dataset:        dataset,
+			Description:    desc,
+			Driver:         driver,
+			ReferenceCount: dataset.ReferenceCount(),
+			Shared:         dataset.IsShared(),
+			Access:         dataset.Access(),
+		}
+	}
+	return infos
+}
+
+// Write a report of the datasets currently open in GDAL, one per line,
+// giving the reference count, the shared flag (S shared, N not shared),
+// the access mode (r read-only, w update), the driver and the description
+func DumpOpenDatasets(w io.Writer) error {
+	for _, info := range OpenDatasetsInfo() {
+		sharedFlag := 'N'
+		if info.Shared {
+			sharedFlag = 'S'
+		}
+		accessFlag := 'r'
+		if info.Access == Update {
+			accessFlag = 'w'
+		}
+		if _, err := fmt.Fprintf(
+			w, "%d %c %c %-6s %s\n",
+			info.ReferenceCount, sharedFlag, accessFlag, info.Driver, info.Description,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 // Return access flag
 func (dataset Dataset) Access() Access {
@@ -1379,6 +1572,67 @@ func (dataset Dataset) ReleaseResultSet(layer Layer) {
 	C.GDALDatasetReleaseResultSet(dataset.cval, layer.cval)
 }
 
+// Begin a transaction on the whole dataset.  Drivers without native
+// transactions emulate them by copying the dataset when force is set, and
+// fail with ErrUnsupportedOperation otherwise.
+func (dataset Dataset) StartTransaction(force bool) error {
+	cErr := C.GDALDatasetStartTransaction(dataset.cval, BoolToCInt(force))
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
+
+// Commit the current dataset transaction
+func (dataset Dataset) CommitTransaction() error {
+	cErr := C.GDALDatasetCommitTransaction(dataset.cval)
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
+
+// Roll back the current dataset transaction
+func (dataset Dataset) RollbackTransaction() error {
+	cErr := C.GDALDatasetRollbackTransaction(dataset.cval)
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
+
+// Run fn in a native dataset transaction, committing it if fn returns nil
+// and rolling it back if fn returns an error or panics.  The panic is
+// propagated once the transaction is rolled back.  If the rollback fails,
+// its error is added to the error returned by fn, which errors.Is still
+// matches, or to the panic value.
+func (dataset Dataset) WithTransaction(fn func() error) (err error) {
+	if err := dataset.StartTransaction(false); err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if r := recover(); r != nil {
+			if rbErr := dataset.RollbackTransaction(); rbErr != nil {
+				panic(withRollbackError(r, rbErr))
+			}
+			panic(r)
+		}
+		if rbErr := dataset.RollbackTransaction(); rbErr != nil {
+			err = withRollbackError(err, rbErr)
+		}
+	}()
+
+	if err := fn(); err != nil {
+		return err
+	}
+	committed = true
+	return dataset.CommitTransaction()
+}
+
+// Add the failure of a rollback to the error, or panic value, that caused
+// it, wrapping the error
+func withRollbackError(cause interface{}, rbErr error) error {
+	if err, ok := cause.(error); ok {
+		return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+	}
+	return fmt.Errorf("%v (rollback failed: %v)", cause, rbErr)
+}
+
 /* ==================================================================== */
 /*      GDALRasterBand ... one band/channel in a dataset.               */
 /* ==================================================================== */
@@ -2188,6 +2442,26 @@ func VSIFReadL(nSize, nCount int, file VSILFILE) []byte {
 	return data
 }
 
+// Write bytes to file, returning the number of bytes written.
+func VSIFWriteL(data []byte, file VSILFILE) int {
+	if len(data) == 0 {
+		return 0
+	}
+	p := unsafe.Pointer(&data[0])
+	written := C.VSIFWriteL(p, C.size_t(1), C.size_t(len(data)), file.cval)
+	return int(written)
+}
+
+// Delete a file.
+func VSIUnlink(fileName string) error {
+	cFileName := C.CString(fileName)
+	defer C.free(unsafe.Pointer(cFileName))
+	if C.VSIUnlink(cFileName) != 0 {
+		return fmt.Errorf("Error: VSI file '%s' unlink error", fileName)
+	}
+	return nil
+}
+
 func ReprojectImage(
 	srcDs, destDs Dataset,
 	srcWkt, destWkt string,
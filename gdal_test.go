@@ -5,6 +5,8 @@
 package gdal
 
 import (
+	"bytes"
+	"errors"
 	"image/png"
 	"os"
 	"testing"
@@ -91,3 +93,126 @@ func TestRegenerateOverviews(t *testing.T) {
 		t.Errorf(err.Error())
 	}
 }
+
+func TestWithConfig(t *testing.T) {
+	err := WithConfig(map[string]string{
+		"GO_GDAL_TEST_OPTION": "inner",
+		"GDAL_NUM_THREADS":    "2",
+	}, func() error {
+		assert.Equal(t, "inner", CPLGetConfigOption("GO_GDAL_TEST_OPTION", ""))
+		assert.Equal(t, "2", CPLGetThreadLocalConfigOption("GDAL_NUM_THREADS", ""))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "", CPLGetThreadLocalConfigOption("GDAL_NUM_THREADS", ""))
+
+	done := make(chan string)
+	go func() {
+		WithConfig(map[string]string{"GO_GDAL_TEST_OPTION": "other"}, func() error {
+			done <- CPLGetConfigOption("GO_GDAL_TEST_OPTION", "")
+			return nil
+		})
+	}()
+	assert.Equal(t, "other", <-done)
+	assert.Equal(t, "", CPLGetConfigOptions()["GO_GDAL_TEST_OPTION"])
+}
+
+func TestOpenDatasets(t *testing.T) {
+	ds, err := Open("testdata/smallgeo.tif", ReadOnly)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer ds.Close()
+	shared := OpenShared("testdata/tiles.gpkg", ReadOnly)
+	defer shared.Close()
+
+	found := map[string]OpenDatasetInfo{}
+	sharedFound := false
+	for _, info := range OpenDatasetsInfo() {
+		found[info.Description] = info
+		if info.Dataset == shared {
+			sharedFound = info.Shared
+		}
+	}
+	if assert.Contains(t, found, "testdata/smallgeo.tif") {
+		info := found["testdata/smallgeo.tif"]
+		assert.Equal(t, 1, info.ReferenceCount)
+		assert.False(t, info.Shared)
+		assert.Equal(t, ReadOnly, info.Access)
+		assert.Equal(t, "GTiff", info.Driver)
+	}
+	assert.True(t, sharedFound)
+	assert.True(t, shared.IsShared())
+	assert.False(t, ds.IsShared())
+
+	ds.GDALReferenceDataset()
+	assert.Equal(t, 2, ds.ReferenceCount())
+	ds.GDALDereferenceDataset()
+	assert.Equal(t, 1, ds.ReferenceCount())
+
+	var report bytes.Buffer
+	assert.NoError(t, DumpOpenDatasets(&report))
+	assert.Contains(t, report.String(), "1 N r GTiff  testdata/smallgeo.tif")
+}
+
+func TestDatasetTransactions(t *testing.T) {
+	driver, err := GetDriverByName("GPKG")
+	if err != nil {
+		t.Skip("GPKG driver not available")
+	}
+	ds := driver.Create(t.TempDir()+"/transactions.gpkg", 0, 0, 0, Unknown, nil)
+	defer ds.Close()
+	parcels := ds.CreateLayer("parcels", SpatialReference{}, GT_Point, nil)
+	owners := ds.CreateLayer("owners", SpatialReference{}, GT_None, nil)
+
+	addFeature := func(layer Layer) {
+		feature := layer.Definition().Create()
+		defer feature.Destroy()
+		assert.NoError(t, layer.Create(feature))
+	}
+	count := func(layer Layer) int {
+		n, _ := layer.FeatureCount(true)
+		return n
+	}
+
+	failure := errors.New("failure")
+	err = ds.WithTransaction(func() error {
+		addFeature(parcels)
+		addFeature(owners)
+		return failure
+	})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 0, count(parcels))
+	assert.Equal(t, 0, count(owners))
+
+	assert.Panics(t, func() {
+		ds.WithTransaction(func() error {
+			addFeature(parcels)
+			panic("failure")
+		})
+	})
+	assert.Equal(t, 0, count(parcels))
+
+	assert.NoError(t, ds.WithTransaction(func() error {
+		addFeature(parcels)
+		addFeature(owners)
+		return nil
+	}))
+	assert.Equal(t, 1, count(parcels))
+	assert.Equal(t, 1, count(owners))
+
+	assert.NoError(t, ds.StartTransaction(false))
+	addFeature(owners)
+	assert.NoError(t, ds.RollbackTransaction())
+	assert.Equal(t, 1, count(owners))
+	assert.NoError(t, ds.StartTransaction(false))
+	addFeature(owners)
+	assert.NoError(t, ds.CommitTransaction())
+	assert.Equal(t, 2, count(owners))
+
+	rbErr := errors.New("rollback")
+	err = withRollbackError(failure, rbErr)
+	assert.True(t, errors.Is(err, failure))
+	assert.Equal(t, "failure (rollback failed: rollback)", err.Error())
+	assert.Equal(t, "panic (rollback failed: rollback)", withRollbackError("panic", rbErr).Error())
+}
@@ -2,4 +2,4 @@ module github.com/lukeroth/gdal
 
 go 1.15
 
-require github.com/stretchr/testify v1.7.2 // indirect
+require github.com/stretchr/testify v1.7.2
@@ -0,0 +1,22 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+#include "go_gdal_dataset.h"
+
+#include <gdal_priv.h>
+
+int go_GDALDatasetIsShared(GDALDatasetH hDS) {
+    GDALDataset* poDS = GDALDataset::FromHandle(hDS);
+    if (poDS == nullptr) {
+        return FALSE;
+    }
+    return poDS->GetShared() ? TRUE : FALSE;
+}
+
+int go_GDALDatasetGetRefCount(GDALDatasetH hDS) {
+    GDALDataset* poDS = GDALDataset::FromHandle(hDS);
+    if (poDS == nullptr) {
+        return 0;
+    }
+    return poDS->GetRefCount();
+}
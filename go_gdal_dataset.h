@@ -0,0 +1,23 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#ifndef GO_GDAL_DATASET_H_
+#define GO_GDAL_DATASET_H_
+
+#include <gdal.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// The shared flag and the reference count of a dataset are only exposed
+// by the GDALDataset C++ class, and are read here without modifying them.
+int go_GDALDatasetIsShared(GDALDatasetH hDS);
+int go_GDALDatasetGetRefCount(GDALDatasetH hDS);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // GO_GDAL_DATASET_H_
@@ -0,0 +1,149 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+#include "go_ogr.h"
+
+#include <gdal_version.h>
+
+const char* go_Fld_GetComment(OGRFieldDefnH hDefn) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+    return OGR_Fld_GetComment(hDefn);
+#else
+    return NULL;
+#endif
+}
+
+int go_Fld_SetComment(OGRFieldDefnH hDefn, const char* pszComment) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+    OGR_Fld_SetComment(hDefn, pszComment);
+    return 1;
+#else
+    return 0;
+#endif
+}
+
+int go_Fld_IsUnique(OGRFieldDefnH hDefn) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 2, 0)
+    return OGR_Fld_IsUnique(hDefn);
+#else
+    return 0;
+#endif
+}
+
+int go_Fld_SetUnique(OGRFieldDefnH hDefn, int bUniqueIn) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 2, 0)
+    OGR_Fld_SetUnique(hDefn, bUniqueIn);
+    return 1;
+#else
+    return 0;
+#endif
+}
+
+void go_GFld_GetCoordinatePrecision(OGRGeomFieldDefnH hDefn, double* pdfXYResolution, double* pdfZResolution, double* pdfMResolution) {
+    *pdfXYResolution = 0;
+    *pdfZResolution = 0;
+    *pdfMResolution = 0;
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+    OGRGeomCoordinatePrecisionH hPrec = OGR_GFld_GetCoordinatePrecision(hDefn);
+    if (hPrec != NULL) {
+        *pdfXYResolution = OGRGeomCoordinatePrecisionGetXYResolution(hPrec);
+        *pdfZResolution = OGRGeomCoordinatePrecisionGetZResolution(hPrec);
+        *pdfMResolution = OGRGeomCoordinatePrecisionGetMResolution(hPrec);
+    }
+#endif
+}
+
+int go_GFld_SetCoordinatePrecision(OGRGeomFieldDefnH hDefn, double dfXYResolution, double dfZResolution, double dfMResolution) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+    OGRGeomCoordinatePrecisionH hPrec = OGRGeomCoordinatePrecisionCreate();
+    OGRGeomCoordinatePrecisionSet(hPrec, dfXYResolution, dfZResolution, dfMResolution);
+    OGR_GFld_SetCoordinatePrecision(hDefn, hPrec);
+    OGRGeomCoordinatePrecisionDestroy(hPrec);
+    return 1;
+#else
+    return 0;
+#endif
+}
+
+int go_GetGEOSVersion(int* pnMajor, int* pnMinor, int* pnPatch) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 4, 0)
+    return OGRGetGEOSVersion(pnMajor, pnMinor, pnPatch) ? 1 : 0;
+#else
+    *pnMajor = 0;
+    *pnMinor = 0;
+    *pnPatch = 0;
+    return -1;
+#endif
+}
+
+OGRGeometryH go_G_MakeValid(OGRGeometryH hGeom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 0, 0)
+    return OGR_G_MakeValid(hGeom);
+#else
+    return NULL;
+#endif
+}
+
+OGRGeometryH go_G_MakeValidEx(OGRGeometryH hGeom, char** papszOptions) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 4, 0)
+    return OGR_G_MakeValidEx(hGeom, papszOptions);
+#else
+    return NULL;
+#endif
+}
+
+OGRGeometryH go_G_Normalize(OGRGeometryH hGeom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 3, 0)
+    return OGR_G_Normalize(hGeom);
+#else
+    return NULL;
+#endif
+}
+
+OGRGeometryH go_G_ConcaveHull(OGRGeometryH hGeom, double dfRatio, int bAllowHoles) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+    return OGR_G_ConcaveHull(hGeom, dfRatio, bAllowHoles != 0);
+#else
+    return NULL;
+#endif
+}
+
+OGRGeometryH go_G_UnaryUnion(OGRGeometryH hGeom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 7, 0)
+    return OGR_G_UnaryUnion(hGeom);
+#else
+    return NULL;
+#endif
+}
+
+OGRGeometryH go_G_BufferEx(OGRGeometryH hGeom, double dfDist, char** papszOptions) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 10, 0)
+    return OGR_G_BufferEx(hGeom, dfDist, papszOptions);
+#else
+    return NULL;
+#endif
+}
+
+double go_G_GeodesicArea(OGRGeometryH hGeom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+    return OGR_G_GeodesicArea(hGeom);
+#else
+    return -1;
+#endif
+}
+
+double go_G_GeodesicLength(OGRGeometryH hGeom) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 10, 0)
+    return OGR_G_GeodesicLength(hGeom);
+#else
+    return -1;
+#endif
+}
+
+OGRGeometryH go_G_SetPrecision(OGRGeometryH hGeom, double dfGridSize, int nFlags) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+    return OGR_G_SetPrecision(hGeom, dfGridSize, nFlags);
+#else
+    return NULL;
+#endif
+}
@@ -0,0 +1,58 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#ifndef GO_OGR_H_
+#define GO_OGR_H_
+
+#include <ogr_api.h>
+
+// go_Fld_GetComment wraps OGR_Fld_GetComment (gdal >= 3.7). Returns NULL
+// on older versions.
+const char* go_Fld_GetComment(OGRFieldDefnH hDefn);
+
+// go_Fld_SetComment wraps OGR_Fld_SetComment (gdal >= 3.7). Returns 0 on
+// older versions.
+int go_Fld_SetComment(OGRFieldDefnH hDefn, const char* pszComment);
+
+// go_Fld_IsUnique wraps OGR_Fld_IsUnique (gdal >= 3.2). Returns 0 on older
+// versions.
+int go_Fld_IsUnique(OGRFieldDefnH hDefn);
+
+// go_Fld_SetUnique wraps OGR_Fld_SetUnique (gdal >= 3.2). Returns 0 on older
+// versions.
+int go_Fld_SetUnique(OGRFieldDefnH hDefn, int bUniqueIn);
+
+// go_GFld_GetCoordinatePrecision wraps OGR_GFld_GetCoordinatePrecision
+// (gdal >= 3.9). The resolutions are left at 0 (unknown) on older versions.
+void go_GFld_GetCoordinatePrecision(OGRGeomFieldDefnH hDefn, double* pdfXYResolution, double* pdfZResolution, double* pdfMResolution);
+
+// go_GFld_SetCoordinatePrecision wraps OGR_GFld_SetCoordinatePrecision
+// (gdal >= 3.9). Returns 0 on older versions.
+int go_GFld_SetCoordinatePrecision(OGRGeomFieldDefnH hDefn, double dfXYResolution, double dfZResolution, double dfMResolution);
+
+// go_GetGEOSVersion wraps OGRGetGEOSVersion (gdal >= 3.4). Returns 1 if
+// gdal is built with GEOS, 0 if not, and -1 on older versions, which cannot
+// report it.
+int go_GetGEOSVersion(int* pnMajor, int* pnMinor, int* pnPatch);
+
+// The geometry operations below wrap the OGR_G functions of the same name,
+// returning NULL when the gdal they are built against is too old:
+// MakeValid (gdal >= 3.0), MakeValidEx (gdal >= 3.4), Normalize
+// (gdal >= 3.3), ConcaveHull (gdal >= 3.6), UnaryUnion (gdal >= 3.7) and
+// BufferEx (gdal >= 3.10).
+OGRGeometryH go_G_MakeValid(OGRGeometryH hGeom);
+OGRGeometryH go_G_MakeValidEx(OGRGeometryH hGeom, char** papszOptions);
+OGRGeometryH go_G_Normalize(OGRGeometryH hGeom);
+OGRGeometryH go_G_ConcaveHull(OGRGeometryH hGeom, double dfRatio, int bAllowHoles);
+OGRGeometryH go_G_UnaryUnion(OGRGeometryH hGeom);
+OGRGeometryH go_G_BufferEx(OGRGeometryH hGeom, double dfDist, char** papszOptions);
+
+// Geodesic measurements (gdal >= 3.9 for areas, gdal >= 3.10 for lengths),
+// returning -1 when not supported, and grid snapping (gdal >= 3.9),
+// returning NULL when not supported.
+double go_G_GeodesicArea(OGRGeometryH hGeom);
+double go_G_GeodesicLength(OGRGeometryH hGeom);
+OGRGeometryH go_G_SetPrecision(OGRGeometryH hGeom, double dfGridSize, int nFlags);
+
+#endif // GO_OGR_H_
@@ -0,0 +1,114 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+#include "go_ogr_arrow.h"
+
+#include <stdlib.h>
+#include <gdal_version.h>
+
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+
+void* go_ArrowArrayStreamNew() {
+    return calloc(1, sizeof(struct ArrowArrayStream));
+}
+
+void go_ArrowArrayStreamFree(void* stream) {
+    struct ArrowArrayStream* s = (struct ArrowArrayStream*)stream;
+    if (s == NULL) {
+        return;
+    }
+    if (s->release != NULL) {
+        s->release(s);
+    }
+    free(s);
+}
+
+int go_ArrowArrayStreamGetSchema(void* stream, void* schema) {
+    struct ArrowArrayStream* s = (struct ArrowArrayStream*)stream;
+    return s->get_schema(s, (struct ArrowSchema*)schema);
+}
+
+int go_ArrowArrayStreamGetNext(void* stream, void* array) {
+    struct ArrowArrayStream* s = (struct ArrowArrayStream*)stream;
+    return s->get_next(s, (struct ArrowArray*)array);
+}
+
+const char* go_ArrowArrayStreamGetLastError(void* stream) {
+    struct ArrowArrayStream* s = (struct ArrowArrayStream*)stream;
+    return s->get_last_error(s);
+}
+
+void* go_ArrowSchemaNew() {
+    return calloc(1, sizeof(struct ArrowSchema));
+}
+
+void go_ArrowSchemaFree(void* schema) {
+    struct ArrowSchema* s = (struct ArrowSchema*)schema;
+    if (s == NULL) {
+        return;
+    }
+    if (s->release != NULL) {
+        s->release(s);
+    }
+    free(s);
+}
+
+void* go_ArrowArrayNew() {
+    return calloc(1, sizeof(struct ArrowArray));
+}
+
+void go_ArrowArrayFree(void* array) {
+    struct ArrowArray* a = (struct ArrowArray*)array;
+    if (a == NULL) {
+        return;
+    }
+    if (a->release != NULL) {
+        a->release(a);
+    }
+    free(a);
+}
+
+int go_ArrowArrayIsReleased(void* array) {
+    return ((struct ArrowArray*)array)->release == NULL;
+}
+
+int64_t go_ArrowArrayLength(void* array) {
+    return ((struct ArrowArray*)array)->length;
+}
+
+int go_OGR_L_GetArrowStream(OGRLayerH hLayer, void* stream, char** papszOptions) {
+    return OGR_L_GetArrowStream(hLayer, (struct ArrowArrayStream*)stream, papszOptions) ? 1 : 0;
+}
+
+#else
+
+void* go_ArrowArrayStreamNew() { return NULL; }
+void go_ArrowArrayStreamFree(void* stream) {}
+int go_ArrowArrayStreamGetSchema(void* stream, void* schema) { return -1; }
+int go_ArrowArrayStreamGetNext(void* stream, void* array) { return -1; }
+const char* go_ArrowArrayStreamGetLastError(void* stream) { return NULL; }
+void* go_ArrowSchemaNew() { return NULL; }
+void go_ArrowSchemaFree(void* schema) {}
+void* go_ArrowArrayNew() { return NULL; }
+void go_ArrowArrayFree(void* array) {}
+int go_ArrowArrayIsReleased(void* array) { return 1; }
+int64_t go_ArrowArrayLength(void* array) { return 0; }
+int go_OGR_L_GetArrowStream(OGRLayerH hLayer, void* stream, char** papszOptions) { return -1; }
+
+#endif
+
+int go_OGR_L_WriteArrowBatch(OGRLayerH hLayer, void* schema, void* array, char** papszOptions) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 8, 0)
+    return OGR_L_WriteArrowBatch(hLayer, (const struct ArrowSchema*)schema, (struct ArrowArray*)array, papszOptions) ? 1 : 0;
+#else
+    return -1;
+#endif
+}
+
+int go_OGR_L_CreateFieldFromArrowSchema(OGRLayerH hLayer, void* schema, char** papszOptions) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 8, 0)
+    return OGR_L_CreateFieldFromArrowSchema(hLayer, (const struct ArrowSchema*)schema, papszOptions) ? 1 : 0;
+#else
+    return -1;
+#endif
+}
@@ -0,0 +1,40 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#ifndef GO_OGR_ARROW_H_
+#define GO_OGR_ARROW_H_
+
+#include <stdint.h>
+#include <ogr_api.h>
+
+// The Arrow C data interface structs (ArrowArrayStream, ArrowSchema,
+// ArrowArray) are only declared by gdal >= 3.6, so they are passed around as
+// void pointers here. The constructors return NULL, and the layer functions
+// return -1, when the running gdal does not support them.
+
+void* go_ArrowArrayStreamNew();
+void go_ArrowArrayStreamFree(void* stream);
+int go_ArrowArrayStreamGetSchema(void* stream, void* schema);
+int go_ArrowArrayStreamGetNext(void* stream, void* array);
+const char* go_ArrowArrayStreamGetLastError(void* stream);
+
+void* go_ArrowSchemaNew();
+void go_ArrowSchemaFree(void* schema);
+
+void* go_ArrowArrayNew();
+void go_ArrowArrayFree(void* array);
+int go_ArrowArrayIsReleased(void* array);
+int64_t go_ArrowArrayLength(void* array);
+
+// go_OGR_L_GetArrowStream wraps OGR_L_GetArrowStream (gdal >= 3.6).
+int go_OGR_L_GetArrowStream(OGRLayerH hLayer, void* stream, char** papszOptions);
+
+// go_OGR_L_WriteArrowBatch wraps OGR_L_WriteArrowBatch (gdal >= 3.8).
+int go_OGR_L_WriteArrowBatch(OGRLayerH hLayer, void* schema, void* array, char** papszOptions);
+
+// go_OGR_L_CreateFieldFromArrowSchema wraps
+// OGR_L_CreateFieldFromArrowSchema (gdal >= 3.8).
+int go_OGR_L_CreateFieldFromArrowSchema(OGRLayerH hLayer, void* schema, char** papszOptions);
+
+#endif // GO_OGR_ARROW_H_
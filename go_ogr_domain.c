@@ -0,0 +1,225 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+#include "go_ogr_domain.h"
+
+#include <gdal_version.h>
+#include <cpl_conv.h>
+#include <stdbool.h>
+#include <string.h>
+
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 3, 0)
+
+static void goFieldFromBound(OGRFieldType eFieldType, const goFieldDomainBound* psBound, OGRField* psField) {
+    switch (eFieldType) {
+    case OFTInteger:
+        psField->Integer = (int)psBound->integer;
+        break;
+    case OFTInteger64:
+        psField->Integer64 = (GIntBig)psBound->integer;
+        break;
+    case OFTReal:
+        psField->Real = psBound->real;
+        break;
+    default:
+        psField->Date.Year = (GInt16)psBound->year;
+        psField->Date.Month = (GByte)psBound->month;
+        psField->Date.Day = (GByte)psBound->day;
+        psField->Date.Hour = (GByte)psBound->hour;
+        psField->Date.Minute = (GByte)psBound->minute;
+        psField->Date.TZFlag = (GByte)psBound->tzFlag;
+        psField->Date.Reserved = 0;
+        psField->Date.Second = psBound->second;
+        break;
+    }
+}
+
+static void goBoundFromField(OGRFieldType eFieldType, const OGRField* psField, int bInclusive, goFieldDomainBound* psBound) {
+    memset(psBound, 0, sizeof(*psBound));
+    if (psField == NULL || OGR_RawField_IsUnset(psField)) {
+        return;
+    }
+    psBound->isSet = 1;
+    psBound->inclusive = bInclusive;
+    switch (eFieldType) {
+    case OFTInteger:
+        psBound->integer = psField->Integer;
+        break;
+    case OFTInteger64:
+        psBound->integer = psField->Integer64;
+        break;
+    case OFTReal:
+        psBound->real = psField->Real;
+        break;
+    default:
+        psBound->year = psField->Date.Year;
+        psBound->month = psField->Date.Month;
+        psBound->day = psField->Date.Day;
+        psBound->hour = psField->Date.Hour;
+        psBound->minute = psField->Date.Minute;
+        psBound->tzFlag = psField->Date.TZFlag;
+        psBound->second = psField->Date.Second;
+        break;
+    }
+}
+
+void go_FldDomain_Destroy(void* hDomain) {
+    OGR_FldDomain_Destroy((OGRFieldDomainH)hDomain);
+}
+
+const char* go_FldDomain_GetName(void* hDomain) {
+    return OGR_FldDomain_GetName((OGRFieldDomainH)hDomain);
+}
+
+const char* go_FldDomain_GetDescription(void* hDomain) {
+    return OGR_FldDomain_GetDescription((OGRFieldDomainH)hDomain);
+}
+
+int go_FldDomain_GetDomainType(void* hDomain) {
+    return (int)OGR_FldDomain_GetDomainType((OGRFieldDomainH)hDomain);
+}
+
+int go_FldDomain_GetFieldType(void* hDomain) {
+    return (int)OGR_FldDomain_GetFieldType((OGRFieldDomainH)hDomain);
+}
+
+int go_FldDomain_GetFieldSubType(void* hDomain) {
+    return (int)OGR_FldDomain_GetFieldSubType((OGRFieldDomainH)hDomain);
+}
+
+int go_FldDomain_GetSplitPolicy(void* hDomain) {
+    return (int)OGR_FldDomain_GetSplitPolicy((OGRFieldDomainH)hDomain);
+}
+
+void go_FldDomain_SetSplitPolicy(void* hDomain, int policy) {
+    OGR_FldDomain_SetSplitPolicy((OGRFieldDomainH)hDomain, (OGRFieldDomainSplitPolicy)policy);
+}
+
+int go_FldDomain_GetMergePolicy(void* hDomain) {
+    return (int)OGR_FldDomain_GetMergePolicy((OGRFieldDomainH)hDomain);
+}
+
+void go_FldDomain_SetMergePolicy(void* hDomain, int policy) {
+    OGR_FldDomain_SetMergePolicy((OGRFieldDomainH)hDomain, (OGRFieldDomainMergePolicy)policy);
+}
+
+void* go_CodedFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, char** papszCodes, char** papszValues, int nCount) {
+    OGRCodedValue* pasValues = (OGRCodedValue*)CPLCalloc(nCount + 1, sizeof(OGRCodedValue));
+    for (int i = 0; i < nCount; i++) {
+        pasValues[i].pszCode = papszCodes[i];
+        pasValues[i].pszValue = papszValues[i];
+    }
+    OGRFieldDomainH hDomain = OGR_CodedFldDomain_Create(
+        pszName, pszDescription, (OGRFieldType)eFieldType, (OGRFieldSubType)eFieldSubType, pasValues);
+    CPLFree(pasValues);
+    return hDomain;
+}
+
+int go_CodedFldDomain_GetCount(void* hDomain) {
+    const OGRCodedValue* pasValues = OGR_CodedFldDomain_GetEnumeration((OGRFieldDomainH)hDomain);
+    int nCount = 0;
+    while (pasValues != NULL && pasValues[nCount].pszCode != NULL) {
+        nCount++;
+    }
+    return nCount;
+}
+
+const char* go_CodedFldDomain_GetCode(void* hDomain, int i) {
+    return OGR_CodedFldDomain_GetEnumeration((OGRFieldDomainH)hDomain)[i].pszCode;
+}
+
+const char* go_CodedFldDomain_GetValue(void* hDomain, int i) {
+    return OGR_CodedFldDomain_GetEnumeration((OGRFieldDomainH)hDomain)[i].pszValue;
+}
+
+void* go_RangeFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, goFieldDomainBound* psMin, goFieldDomainBound* psMax) {
+    OGRField sMin, sMax;
+    goFieldFromBound((OGRFieldType)eFieldType, psMin, &sMin);
+    goFieldFromBound((OGRFieldType)eFieldType, psMax, &sMax);
+    return OGR_RangeFldDomain_Create(
+        pszName, pszDescription, (OGRFieldType)eFieldType, (OGRFieldSubType)eFieldSubType,
+        psMin->isSet ? &sMin : NULL, psMin->inclusive != 0,
+        psMax->isSet ? &sMax : NULL, psMax->inclusive != 0);
+}
+
+void go_RangeFldDomain_GetMin(void* hDomain, goFieldDomainBound* psMin) {
+    bool bInclusive = false;
+    const OGRField* psField = OGR_RangeFldDomain_GetMin((OGRFieldDomainH)hDomain, &bInclusive);
+    goBoundFromField(OGR_FldDomain_GetFieldType((OGRFieldDomainH)hDomain), psField, bInclusive, psMin);
+}
+
+void go_RangeFldDomain_GetMax(void* hDomain, goFieldDomainBound* psMax) {
+    bool bInclusive = false;
+    const OGRField* psField = OGR_RangeFldDomain_GetMax((OGRFieldDomainH)hDomain, &bInclusive);
+    goBoundFromField(OGR_FldDomain_GetFieldType((OGRFieldDomainH)hDomain), psField, bInclusive, psMax);
+}
+
+void* go_GlobFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, const char* pszGlob) {
+    return OGR_GlobFldDomain_Create(
+        pszName, pszDescription, (OGRFieldType)eFieldType, (OGRFieldSubType)eFieldSubType, pszGlob);
+}
+
+const char* go_GlobFldDomain_GetGlob(void* hDomain) {
+    return OGR_GlobFldDomain_GetGlob((OGRFieldDomainH)hDomain);
+}
+
+void* go_DatasetGetFieldDomain(GDALDatasetH hDS, const char* pszName) {
+    return (void*)GDALDatasetGetFieldDomain(hDS, pszName);
+}
+
+int go_DatasetAddFieldDomain(GDALDatasetH hDS, void* hDomain, char** ppszFailureReason) {
+    return GDALDatasetAddFieldDomain(hDS, (OGRFieldDomainH)hDomain, ppszFailureReason) ? 1 : 0;
+}
+
+const char* go_Fld_GetDomainName(OGRFieldDefnH hDefn) {
+    return OGR_Fld_GetDomainName(hDefn);
+}
+
+int go_Fld_SetDomainName(OGRFieldDefnH hDefn, const char* pszName) {
+    OGR_Fld_SetDomainName(hDefn, pszName);
+    return 1;
+}
+
+#else
+
+void go_FldDomain_Destroy(void* hDomain) {}
+const char* go_FldDomain_GetName(void* hDomain) { return NULL; }
+const char* go_FldDomain_GetDescription(void* hDomain) { return NULL; }
+int go_FldDomain_GetDomainType(void* hDomain) { return -1; }
+int go_FldDomain_GetFieldType(void* hDomain) { return -1; }
+int go_FldDomain_GetFieldSubType(void* hDomain) { return -1; }
+int go_FldDomain_GetSplitPolicy(void* hDomain) { return -1; }
+void go_FldDomain_SetSplitPolicy(void* hDomain, int policy) {}
+int go_FldDomain_GetMergePolicy(void* hDomain) { return -1; }
+void go_FldDomain_SetMergePolicy(void* hDomain, int policy) {}
+void* go_CodedFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, char** papszCodes, char** papszValues, int nCount) { return NULL; }
+int go_CodedFldDomain_GetCount(void* hDomain) { return 0; }
+const char* go_CodedFldDomain_GetCode(void* hDomain, int i) { return NULL; }
+const char* go_CodedFldDomain_GetValue(void* hDomain, int i) { return NULL; }
+void* go_RangeFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, goFieldDomainBound* psMin, goFieldDomainBound* psMax) { return NULL; }
+void go_RangeFldDomain_GetMin(void* hDomain, goFieldDomainBound* psMin) { memset(psMin, 0, sizeof(*psMin)); }
+void go_RangeFldDomain_GetMax(void* hDomain, goFieldDomainBound* psMax) { memset(psMax, 0, sizeof(*psMax)); }
+void* go_GlobFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, const char* pszGlob) { return NULL; }
+const char* go_GlobFldDomain_GetGlob(void* hDomain) { return NULL; }
+void* go_DatasetGetFieldDomain(GDALDatasetH hDS, const char* pszName) { return NULL; }
+int go_DatasetAddFieldDomain(GDALDatasetH hDS, void* hDomain, char** ppszFailureReason) { return -1; }
+const char* go_Fld_GetDomainName(OGRFieldDefnH hDefn) { return NULL; }
+int go_Fld_SetDomainName(OGRFieldDefnH hDefn, const char* pszName) { return 0; }
+
+#endif
+
+char** go_DatasetGetFieldDomainNames(GDALDatasetH hDS, char** papszOptions) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+    return GDALDatasetGetFieldDomainNames(hDS, papszOptions);
+#else
+    return NULL;
+#endif
+}
+
+int go_DatasetDeleteFieldDomain(GDALDatasetH hDS, const char* pszName, char** ppszFailureReason) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+    return GDALDatasetDeleteFieldDomain(hDS, pszName, ppszFailureReason) ? 1 : 0;
+#else
+    return -1;
+#endif
+}
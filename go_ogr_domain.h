@@ -0,0 +1,60 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#ifndef GO_OGR_DOMAIN_H_
+#define GO_OGR_DOMAIN_H_
+
+#include <gdal.h>
+#include <ogr_api.h>
+
+// Field domains are only available from gdal 3.3 (listing and deleting them
+// from 3.5), so domain handles are passed around as void pointers and the
+// wrappers below fail, returning NULL, 0 or -1, when the running gdal does
+// not support them.
+
+// goFieldDomainBound holds one end of a range field domain. isSet is 0 for
+// an unbounded end.
+typedef struct {
+    int isSet;
+    int inclusive;
+    long long integer;
+    double real;
+    int year, month, day, hour, minute, tzFlag;
+    float second;
+} goFieldDomainBound;
+
+void go_FldDomain_Destroy(void* hDomain);
+const char* go_FldDomain_GetName(void* hDomain);
+const char* go_FldDomain_GetDescription(void* hDomain);
+int go_FldDomain_GetDomainType(void* hDomain);
+int go_FldDomain_GetFieldType(void* hDomain);
+int go_FldDomain_GetFieldSubType(void* hDomain);
+int go_FldDomain_GetSplitPolicy(void* hDomain);
+void go_FldDomain_SetSplitPolicy(void* hDomain, int policy);
+int go_FldDomain_GetMergePolicy(void* hDomain);
+void go_FldDomain_SetMergePolicy(void* hDomain, int policy);
+
+void* go_CodedFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, char** papszCodes, char** papszValues, int nCount);
+int go_CodedFldDomain_GetCount(void* hDomain);
+const char* go_CodedFldDomain_GetCode(void* hDomain, int i);
+const char* go_CodedFldDomain_GetValue(void* hDomain, int i);
+
+void* go_RangeFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, goFieldDomainBound* psMin, goFieldDomainBound* psMax);
+void go_RangeFldDomain_GetMin(void* hDomain, goFieldDomainBound* psMin);
+void go_RangeFldDomain_GetMax(void* hDomain, goFieldDomainBound* psMax);
+
+void* go_GlobFldDomain_Create(const char* pszName, const char* pszDescription, int eFieldType, int eFieldSubType, const char* pszGlob);
+const char* go_GlobFldDomain_GetGlob(void* hDomain);
+
+// go_DatasetGetFieldDomainNames returns NULL before gdal 3.5.
+char** go_DatasetGetFieldDomainNames(GDALDatasetH hDS, char** papszOptions);
+void* go_DatasetGetFieldDomain(GDALDatasetH hDS, const char* pszName);
+int go_DatasetAddFieldDomain(GDALDatasetH hDS, void* hDomain, char** ppszFailureReason);
+// go_DatasetDeleteFieldDomain returns -1 before gdal 3.5.
+int go_DatasetDeleteFieldDomain(GDALDatasetH hDS, const char* pszName, char** ppszFailureReason);
+
+const char* go_Fld_GetDomainName(OGRFieldDefnH hDefn);
+int go_Fld_SetDomainName(OGRFieldDefnH hDefn, const char* pszName);
+
+#endif // GO_OGR_DOMAIN_H_
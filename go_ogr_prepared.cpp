@@ -0,0 +1,87 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+#include "go_ogr_prepared.h"
+
+#include <ogr_geometry.h>
+
+struct goPreparedGeometry {
+    OGRPreparedGeometry* poPrepared;
+    // ContainsProperly holds when the other geometry is contained and does
+    // not touch the boundary.  The boundary is computed and prepared from
+    // a copy of the geometry on first use.
+    OGRGeometry* poGeom;
+    bool bBoundaryComputed;
+    OGRGeometry* poBoundary;
+    OGRPreparedGeometry* poPreparedBoundary;
+};
+
+int go_HasPreparedGeometrySupport() {
+    return OGRHasPreparedGeometrySupport();
+}
+
+goPreparedGeometry* go_CreatePreparedGeometry(OGRGeometryH hGeom) {
+    OGRGeometry* poGeom = OGRGeometry::FromHandle(hGeom);
+    if (poGeom == nullptr) {
+        return nullptr;
+    }
+    OGRPreparedGeometry* poPrepared = OGRCreatePreparedGeometry(poGeom);
+    if (poPrepared == nullptr) {
+        return nullptr;
+    }
+    goPreparedGeometry* psPrepared = new goPreparedGeometry();
+    psPrepared->poPrepared = poPrepared;
+    psPrepared->poGeom = poGeom->clone();
+    psPrepared->bBoundaryComputed = false;
+    psPrepared->poBoundary = nullptr;
+    psPrepared->poPreparedBoundary = nullptr;
+    return psPrepared;
+}
+
+void go_DestroyPreparedGeometry(goPreparedGeometry* psPrepared) {
+    if (psPrepared == nullptr) {
+        return;
+    }
+    OGRDestroyPreparedGeometry(psPrepared->poPrepared);
+    if (psPrepared->poPreparedBoundary != nullptr) {
+        OGRDestroyPreparedGeometry(psPrepared->poPreparedBoundary);
+    }
+    delete psPrepared->poBoundary;
+    delete psPrepared->poGeom;
+    delete psPrepared;
+}
+
+int go_PreparedGeometryIntersects(goPreparedGeometry* psPrepared, OGRGeometryH hOther) {
+    return OGRPreparedGeometryIntersects(psPrepared->poPrepared, OGRGeometry::FromHandle(hOther));
+}
+
+int go_PreparedGeometryContains(goPreparedGeometry* psPrepared, OGRGeometryH hOther) {
+    return OGRPreparedGeometryContains(psPrepared->poPrepared, OGRGeometry::FromHandle(hOther));
+}
+
+int go_PreparedGeometryContainsProperly(goPreparedGeometry* psPrepared, OGRGeometryH hOther) {
+    const OGRGeometry* poOther = OGRGeometry::FromHandle(hOther);
+    if (!psPrepared->bBoundaryComputed) {
+        psPrepared->bBoundaryComputed = true;
+        psPrepared->poBoundary = psPrepared->poGeom->Boundary();
+        if (psPrepared->poBoundary != nullptr && !psPrepared->poBoundary->IsEmpty()) {
+            psPrepared->poPreparedBoundary = OGRCreatePreparedGeometry(psPrepared->poBoundary);
+        }
+    }
+    // GEOS has no boundary for some geometries, such as collections
+    if (psPrepared->poBoundary == nullptr) {
+        return -1;
+    }
+    if (!OGRPreparedGeometryContains(psPrepared->poPrepared, poOther)) {
+        return FALSE;
+    }
+    // Points and closed lines have an empty boundary: their interior is the
+    // whole geometry, and ContainsProperly is the same as Contains
+    if (psPrepared->poBoundary->IsEmpty()) {
+        return TRUE;
+    }
+    if (psPrepared->poPreparedBoundary == nullptr) {
+        return !psPrepared->poBoundary->Intersects(poOther);
+    }
+    return !OGRPreparedGeometryIntersects(psPrepared->poPreparedBoundary, poOther);
+}
@@ -0,0 +1,34 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#ifndef GO_OGR_PREPARED_H_
+#define GO_OGR_PREPARED_H_
+
+#include <ogr_api.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// The prepared geometry functions of OGR are only exported with C++
+// linkage, so they are wrapped here behind an opaque handle.
+typedef struct goPreparedGeometry goPreparedGeometry;
+
+int go_HasPreparedGeometrySupport();
+
+// go_CreatePreparedGeometry returns NULL if the geometry cannot be prepared,
+// for instance when gdal was built without GEOS.
+goPreparedGeometry* go_CreatePreparedGeometry(OGRGeometryH hGeom);
+void go_DestroyPreparedGeometry(goPreparedGeometry* psPrepared);
+int go_PreparedGeometryIntersects(goPreparedGeometry* psPrepared, OGRGeometryH hOther);
+int go_PreparedGeometryContains(goPreparedGeometry* psPrepared, OGRGeometryH hOther);
+// go_PreparedGeometryContainsProperly returns -1 if the boundary of the
+// prepared geometry cannot be computed.
+int go_PreparedGeometryContainsProperly(goPreparedGeometry* psPrepared, OGRGeometryH hOther);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // GO_OGR_PREPARED_H_
@@ -34,3 +34,27 @@ OGRErr go_ExportToWkb(OGRGeometryH hGeom, OGRwkbByteOrder eOrder, unsigned char*
 }
 
 #endif // GDAL_COMPUTE_VERSION(GDAL_VERSION_MAJOR, GDAL_VERSION_MINOR, GDAL_VERSION_PATCH) >= GDAL_COMPUTE_VERSION(2, 3, 0)
+
+OGRErr go_ExportToIsoWkb(OGRGeometryH hGeom, OGRwkbByteOrder eOrder, unsigned char* pabyDstBuffer) {
+    return OGR_G_ExportToIsoWkb(hGeom, eOrder, pabyDstBuffer);
+}
+
+OGRErr go_ExportToWkbEx(OGRGeometryH hGeom, OGRwkbByteOrder eOrder, OGRwkbVariant eVariant, unsigned char* pabyDstBuffer) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 9, 0)
+    OGRwkbExportOptions* psOptions = OGRwkbExportOptionsCreate();
+    OGRwkbExportOptionsSetByteOrder(psOptions, eOrder);
+    OGRwkbExportOptionsSetVariant(psOptions, eVariant);
+    OGRErr eErr = OGR_G_ExportToWkbEx(hGeom, pabyDstBuffer, psOptions);
+    OGRwkbExportOptionsDestroy(psOptions);
+    return eErr;
+#else
+    switch (eVariant) {
+    case wkbVariantOldOgc:
+        return OGR_G_ExportToWkb(hGeom, eOrder, pabyDstBuffer);
+    case wkbVariantIso:
+        return OGR_G_ExportToIsoWkb(hGeom, eOrder, pabyDstBuffer);
+    default:
+        return OGRERR_UNSUPPORTED_OPERATION;
+    }
+#endif
+}
@@ -15,4 +15,11 @@ OGRErr go_ImportFromWkb(OGRGeometryH hGeom, void* pabyData, int nSize);
 // go_ExportToWkb helps us in handling an API/ABI break introduced by gdal 2.3.0.
 OGRErr go_ExportToWkb(OGRGeometryH hGeom, OGRwkbByteOrder eOrder, unsigned char* pabyDstBuffer);
 
+// go_ExportToIsoWkb wraps OGR_G_ExportToIsoWkb, which keeps M values.
+OGRErr go_ExportToIsoWkb(OGRGeometryH hGeom, OGRwkbByteOrder eOrder, unsigned char* pabyDstBuffer);
+
+// go_ExportToWkbEx exports any WKB variant with gdal >= 3.9, and the old
+// OGC and ISO variants otherwise.
+OGRErr go_ExportToWkbEx(OGRGeometryH hGeom, OGRwkbByteOrder eOrder, OGRwkbVariant eVariant, unsigned char* pabyDstBuffer);
+
 #endif  // GO_OGR_WKB_H_
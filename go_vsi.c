@@ -0,0 +1,30 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+#include "go_vsi.h"
+
+#include <gdal_version.h>
+
+int go_VSISetPathSpecificOption(const char* pszPathPrefix, const char* pszKey, const char* pszValue) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+    VSISetPathSpecificOption(pszPathPrefix, pszKey, pszValue);
+    return 1;
+#elif GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+    VSISetCredential(pszPathPrefix, pszKey, pszValue);
+    return 1;
+#else
+    return 0;
+#endif
+}
+
+int go_VSIClearPathSpecificOptions(const char* pszPathPrefix) {
+#if GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 6, 0)
+    VSIClearPathSpecificOptions(pszPathPrefix);
+    return 1;
+#elif GDAL_VERSION_NUM >= GDAL_COMPUTE_VERSION(3, 5, 0)
+    VSIClearCredentials(pszPathPrefix);
+    return 1;
+#else
+    return 0;
+#endif
+}
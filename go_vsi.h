@@ -0,0 +1,20 @@
+// Copyright 2011 go-gdal. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#ifndef GO_VSI_H_
+#define GO_VSI_H_
+
+#include <cpl_vsi.h>
+
+// go_VSISetPathSpecificOption wraps VSISetPathSpecificOption (gdal >= 3.6)
+// and its predecessor VSISetCredential (gdal 3.5). Returns 0 if neither is
+// available.
+int go_VSISetPathSpecificOption(const char* pszPathPrefix, const char* pszKey, const char* pszValue);
+
+// go_VSIClearPathSpecificOptions wraps VSIClearPathSpecificOptions
+// (gdal >= 3.6) and its predecessor VSIClearCredentials (gdal 3.5). Returns 0
+// if neither is available.
+int go_VSIClearPathSpecificOptions(const char* pszPathPrefix);
+
+#endif // GO_VSI_H_
@@ -0,0 +1,425 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+/* ==================================================================== */
+/*      Managed handles and leak detection                              */
+/* ==================================================================== */
+
+// The plain wrapper types (Dataset, Geometry, Feature, ...) are copied by
+// value and must be released by hand exactly once.  The Managed* types
+// below are an opt-in alternative: they are used through a pointer, their
+// Close/Destroy is idempotent, and a finalizer releases the C handle if the
+// wrapper is garbage collected while still open.  A managed wrapper owns
+// its handle, so the value returned by Get must not be released separately.
+//
+// The value returned by Get is a copy of the handle that the garbage
+// collector does not associate with the managed wrapper.  Once the wrapper
+// itself is no longer used, its finalizer may run, even during a call on
+// the copy.  Either work on the value inside Use, which keeps the wrapper
+// alive until the function returns, or call runtime.KeepAlive on the
+// wrapper after the last use of the value.  The same holds for the handles
+// fetched from the value, such as the layers and raster bands of a dataset
+// or the geometry of a feature: they are only valid while the wrapper is
+// alive.
+
+// HandleInfo describes a live C handle owned by a managed wrapper
+type HandleInfo struct {
+	// Kind is the wrapper type, e.g. "Dataset" or "Geometry"
+	Kind string
+	// Handle is the address of the C object
+	Handle uintptr
+	// Stack is the goroutine stack at the time the handle was wrapped,
+	// recorded only while handle tracking is enabled
+	Stack string
+}
+
+var handles = struct {
+	sync.Mutex
+	tracking bool
+	live     map[uintptr]HandleInfo
+	onLeak   func(HandleInfo)
+}{live: make(map[uintptr]HandleInfo)}
+
+// Enable or disable recording of managed handles and their allocation
+// stack traces.  Tracking has a cost and is intended for debugging.
+func SetHandleTracking(enabled bool) {
+	handles.Lock()
+	defer handles.Unlock()
+	handles.tracking = enabled
+	if !enabled {
+		handles.live = make(map[uintptr]HandleInfo)
+	}
+}
+
+// Set a function called when a managed handle is released by its finalizer
+// instead of an explicit Close or Destroy.  The function runs on the
+// finalizer goroutine and must not block.
+func SetLeakHandler(fn func(HandleInfo)) {
+	handles.Lock()
+	defer handles.Unlock()
+	handles.onLeak = fn
+}
+
+// Return the managed handles that are still open, ordered by handle
+// address.  Only handles wrapped while tracking was enabled are reported.
+func LiveHandles() []HandleInfo {
+	handles.Lock()
+	defer handles.Unlock()
+	infos := make([]HandleInfo, 0, len(handles.live))
+	for _, info := range handles.live {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Handle < infos[j].Handle })
+	return infos
+}
+
+// Write a report of the live managed handles, with their allocation stack
+// traces, followed by the datasets GDAL holds open that are not managed.
+func DumpLiveHandles(w io.Writer) error {
+	infos := LiveHandles()
+	managed := make(map[uintptr]bool, len(infos))
+	for _, info := range infos {
+		managed[info.Handle] = true
+		if _, err := fmt.Fprintf(w, "%s %#x\n%s\n", info.Kind, info.Handle, info.Stack); err != nil {
+			return err
+		}
+	}
+	for _, dataset := range OpenDatasets() {
+		if managed[uintptr(unsafe.Pointer(dataset.cval))] {
+			continue
+		}
+		desc := C.GoString(C.GDALGetDescription(C.GDALMajorObjectH(unsafe.Pointer(dataset.cval))))
+		if _, err := fmt.Fprintf(w, "Dataset %#x (unmanaged) %s\n", uintptr(unsafe.Pointer(dataset.cval)), desc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func trackHandle(kind string, handle unsafe.Pointer) {
+	handles.Lock()
+	defer handles.Unlock()
+	if !handles.tracking {
+		return
+	}
+	stack := make([]byte, 8192)
+	stack = stack[:runtime.Stack(stack, false)]
+	handles.live[uintptr(handle)] = HandleInfo{
+		Kind:   kind,
+		Handle: uintptr(handle),
+		Stack:  string(stack),
+	}
+}
+
+func untrackHandle(handle unsafe.Pointer) {
+	handles.Lock()
+	defer handles.Unlock()
+	delete(handles.live, uintptr(handle))
+}
+
+// leakedHandle is called by finalizers before they release a handle
+func leakedHandle(kind string, handle unsafe.Pointer) {
+	handles.Lock()
+	info, ok := handles.live[uintptr(handle)]
+	delete(handles.live, uintptr(handle))
+	onLeak := handles.onLeak
+	handles.Unlock()
+
+	if !ok {
+		info = HandleInfo{Kind: kind, Handle: uintptr(handle)}
+	}
+	if onLeak != nil {
+		onLeak(info)
+	}
+}
+
+/* -------------------------------------------------------------------- */
+/*      Managed Dataset                                                 */
+/* -------------------------------------------------------------------- */
+
+type ManagedDataset struct {
+	dataset Dataset
+}
+
+// Take ownership of a dataset.  Layers and raster bands fetched from the
+// dataset are handles into it, so they must be used inside Use, or md kept
+// alive otherwise, for the finalizer not to close the dataset under them.
+func ManageDataset(dataset Dataset) *ManagedDataset {
+	md := &ManagedDataset{dataset}
+	if dataset.cval != nil {
+		trackHandle("Dataset", unsafe.Pointer(dataset.cval))
+		runtime.SetFinalizer(md, func(md *ManagedDataset) {
+			leakedHandle("Dataset", unsafe.Pointer(md.dataset.cval))
+			md.dataset.Close()
+		})
+	}
+	return md
+}
+
+// Return the dataset, or a null dataset once closed
+func (md *ManagedDataset) Get() Dataset {
+	return md.dataset
+}
+
+// Call fn with the dataset, keeping md alive until fn returns
+func (md *ManagedDataset) Use(fn func(Dataset) error) error {
+	err := fn(md.dataset)
+	runtime.KeepAlive(md)
+	return err
+}
+
+// Close the dataset.  Closing an already closed dataset does nothing.
+func (md *ManagedDataset) Close() {
+	if md.dataset.cval == nil {
+		return
+	}
+	runtime.SetFinalizer(md, nil)
+	untrackHandle(unsafe.Pointer(md.dataset.cval))
+	md.dataset.Close()
+	md.dataset.cval = nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Managed Geometry                                                */
+/* -------------------------------------------------------------------- */
+
+type ManagedGeometry struct {
+	geom Geometry
+}
+
+// Take ownership of a geometry.  Only geometries owned by the caller (not
+// references into a feature or another geometry) may be managed.
+func ManageGeometry(geom Geometry) *ManagedGeometry {
+	mg := &ManagedGeometry{geom}
+	if geom.cval != nil {
+		trackHandle("Geometry", unsafe.Pointer(geom.cval))
+		runtime.SetFinalizer(mg, func(mg *ManagedGeometry) {
+			leakedHandle("Geometry", unsafe.Pointer(mg.geom.cval))
+			mg.geom.Destroy()
+		})
+	}
+	return mg
+}
+
+// Return the geometry, or a null geometry once destroyed
+func (mg *ManagedGeometry) Get() Geometry {
+	return mg.geom
+}
+
+// Call fn with the geometry, keeping mg alive until fn returns
+func (mg *ManagedGeometry) Use(fn func(Geometry) error) error {
+	err := fn(mg.geom)
+	runtime.KeepAlive(mg)
+	return err
+}
+
+// Destroy the geometry.  Destroying an already destroyed geometry does
+// nothing.
+func (mg *ManagedGeometry) Destroy() {
+	if mg.geom.cval == nil {
+		return
+	}
+	runtime.SetFinalizer(mg, nil)
+	untrackHandle(unsafe.Pointer(mg.geom.cval))
+	mg.geom.Destroy()
+	mg.geom.cval = nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Managed Feature                                                 */
+/* -------------------------------------------------------------------- */
+
+type ManagedFeature struct {
+	feature Feature
+}
+
+// Take ownership of a feature
+func ManageFeature(feature Feature) *ManagedFeature {
+	mf := &ManagedFeature{feature}
+	if feature.cval != nil {
+		trackHandle("Feature", unsafe.Pointer(feature.cval))
+		runtime.SetFinalizer(mf, func(mf *ManagedFeature) {
+			leakedHandle("Feature", unsafe.Pointer(mf.feature.cval))
+			mf.feature.Destroy()
+		})
+	}
+	return mf
+}
+
+// Return the feature, or a null feature once destroyed
+func (mf *ManagedFeature) Get() Feature {
+	return mf.feature
+}
+
+// Call fn with the feature, keeping mf alive until fn returns
+func (mf *ManagedFeature) Use(fn func(Feature) error) error {
+	err := fn(mf.feature)
+	runtime.KeepAlive(mf)
+	return err
+}
+
+// Destroy the feature.  Destroying an already destroyed feature does
+// nothing.
+func (mf *ManagedFeature) Destroy() {
+	if mf.feature.cval == nil {
+		return
+	}
+	runtime.SetFinalizer(mf, nil)
+	untrackHandle(unsafe.Pointer(mf.feature.cval))
+	mf.feature.Destroy()
+	mf.feature.cval = nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Managed SpatialReference                                        */
+/* -------------------------------------------------------------------- */
+
+type ManagedSpatialReference struct {
+	sr SpatialReference
+}
+
+// Take ownership of a reference to a spatial reference.  The reference is
+// dropped with Release, so the object survives while geometries or layers
+// still reference it.
+func ManageSpatialReference(sr SpatialReference) *ManagedSpatialReference {
+	msr := &ManagedSpatialReference{sr}
+	if sr.cval != nil {
+		trackHandle("SpatialReference", unsafe.Pointer(sr.cval))
+		runtime.SetFinalizer(msr, func(msr *ManagedSpatialReference) {
+			leakedHandle("SpatialReference", unsafe.Pointer(msr.sr.cval))
+			msr.sr.Release()
+		})
+	}
+	return msr
+}
+
+// Return the spatial reference, or a null spatial reference once released
+func (msr *ManagedSpatialReference) Get() SpatialReference {
+	return msr.sr
+}
+
+// Call fn with the spatial reference, keeping msr alive until fn returns
+func (msr *ManagedSpatialReference) Use(fn func(SpatialReference) error) error {
+	err := fn(msr.sr)
+	runtime.KeepAlive(msr)
+	return err
+}
+
+// Drop the reference.  Dropping an already dropped reference does nothing.
+func (msr *ManagedSpatialReference) Release() {
+	if msr.sr.cval == nil {
+		return
+	}
+	runtime.SetFinalizer(msr, nil)
+	untrackHandle(unsafe.Pointer(msr.sr.cval))
+	msr.sr.Release()
+	msr.sr.cval = nil
+}
+
+// Destroy is an alias for Release
+func (msr *ManagedSpatialReference) Destroy() {
+	msr.Release()
+}
+
+/* -------------------------------------------------------------------- */
+/*      Managed ColorTable                                              */
+/* -------------------------------------------------------------------- */
+
+type ManagedColorTable struct {
+	ct ColorTable
+}
+
+// Take ownership of a color table.  Color tables fetched from a raster
+// band belong to the band and must not be managed.
+func ManageColorTable(ct ColorTable) *ManagedColorTable {
+	mct := &ManagedColorTable{ct}
+	if ct.cval != nil {
+		trackHandle("ColorTable", unsafe.Pointer(ct.cval))
+		runtime.SetFinalizer(mct, func(mct *ManagedColorTable) {
+			leakedHandle("ColorTable", unsafe.Pointer(mct.ct.cval))
+			mct.ct.Destroy()
+		})
+	}
+	return mct
+}
+
+// Return the color table, or a null color table once destroyed
+func (mct *ManagedColorTable) Get() ColorTable {
+	return mct.ct
+}
+
+// Call fn with the color table, keeping mct alive until fn returns
+func (mct *ManagedColorTable) Use(fn func(ColorTable) error) error {
+	err := fn(mct.ct)
+	runtime.KeepAlive(mct)
+	return err
+}
+
+// Destroy the color table.  Destroying an already destroyed color table
+// does nothing.
+func (mct *ManagedColorTable) Destroy() {
+	if mct.ct.cval == nil {
+		return
+	}
+	runtime.SetFinalizer(mct, nil)
+	untrackHandle(unsafe.Pointer(mct.ct.cval))
+	mct.ct.Destroy()
+	mct.ct.cval = nil
+}
+
+/* -------------------------------------------------------------------- */
+/*      Managed CoordinateTransform                                     */
+/* -------------------------------------------------------------------- */
+
+type ManagedCoordinateTransform struct {
+	ct CoordinateTransform
+}
+
+// Take ownership of a coordinate transform
+func ManageCoordinateTransform(ct CoordinateTransform) *ManagedCoordinateTransform {
+	mct := &ManagedCoordinateTransform{ct}
+	if ct.cval != nil {
+		trackHandle("CoordinateTransform", unsafe.Pointer(ct.cval))
+		runtime.SetFinalizer(mct, func(mct *ManagedCoordinateTransform) {
+			leakedHandle("CoordinateTransform", unsafe.Pointer(mct.ct.cval))
+			mct.ct.Destroy()
+		})
+	}
+	return mct
+}
+
+// Return the transform, or a null transform once destroyed
+func (mct *ManagedCoordinateTransform) Get() CoordinateTransform {
+	return mct.ct
+}
+
+// Call fn with the transform, keeping mct alive until fn returns
+func (mct *ManagedCoordinateTransform) Use(fn func(CoordinateTransform) error) error {
+	err := fn(mct.ct)
+	runtime.KeepAlive(mct)
+	return err
+}
+
+// Destroy the coordinate transform.  Destroying an already destroyed
+// transform does nothing.
+func (mct *ManagedCoordinateTransform) Destroy() {
+	if mct.ct.cval == nil {
+		return
+	}
+	runtime.SetFinalizer(mct, nil)
+	untrackHandle(unsafe.Pointer(mct.ct.cval))
+	mct.ct.Destroy()
+	mct.ct.cval = nil
+}
@@ -0,0 +1,136 @@
+package gdal
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedDatasetClose(t *testing.T) {
+	SetHandleTracking(true)
+	defer SetHandleTracking(false)
+
+	ds, err := Open("testdata/smallgeo.tif", ReadOnly)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	md := ManageDataset(ds)
+
+	live := LiveHandles()
+	if assert.Len(t, live, 1) {
+		assert.Equal(t, "Dataset", live[0].Kind)
+		assert.Contains(t, live[0].Stack, "TestManagedDatasetClose")
+	}
+
+	var report bytes.Buffer
+	assert.NoError(t, DumpLiveHandles(&report))
+	assert.Contains(t, report.String(), "TestManagedDatasetClose")
+
+	md.Close()
+	md.Close()
+	assert.True(t, md.Get().IsNull())
+	assert.Len(t, LiveHandles(), 0)
+}
+
+func TestManagedDatasetFinalizer(t *testing.T) {
+	SetHandleTracking(true)
+	defer SetHandleTracking(false)
+
+	leaks := make(chan HandleInfo, 1)
+	SetLeakHandler(func(info HandleInfo) { leaks <- info })
+	defer SetLeakHandler(nil)
+
+	func() {
+		ds, err := Open("testdata/smallgeo.tif", ReadOnly)
+		if err != nil {
+			t.Fatalf("failed to open test file: %v", err)
+		}
+		md := ManageDataset(ds)
+		// bands fetched inside Use stay valid while garbage is collected
+		assert.NoError(t, md.Use(func(ds Dataset) error {
+			band := ds.RasterBand(1)
+			runtime.GC()
+			runtime.GC()
+			assert.Equal(t, ds.RasterXSize(), band.XSize())
+			return nil
+		}))
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case info := <-leaks:
+			assert.Equal(t, "Dataset", info.Kind)
+			assert.Contains(t, info.Stack, "TestManagedDatasetFinalizer")
+			assert.Len(t, LiveHandles(), 0)
+			return
+		case <-deadline:
+			t.Fatal("finalizer did not close the dataset")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestManagedGeometryFinalizer(t *testing.T) {
+	SetHandleTracking(true)
+	defer SetHandleTracking(false)
+
+	leaks := make(chan HandleInfo, 1)
+	SetLeakHandler(func(info HandleInfo) { leaks <- info })
+	defer SetLeakHandler(nil)
+
+	func() {
+		geom, _ := CreateFromWKT("POINT (1 2)", SpatialReference{})
+		ManageGeometry(geom)
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case info := <-leaks:
+			assert.Equal(t, "Geometry", info.Kind)
+			assert.Contains(t, info.Stack, "TestManagedGeometryFinalizer")
+			return
+		case <-deadline:
+			t.Fatal("finalizer did not release the geometry")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestManagedGeometryKeepAlive(t *testing.T) {
+	var mu sync.Mutex
+	leaked := make(map[uintptr]bool)
+	SetLeakHandler(func(info HandleInfo) {
+		mu.Lock()
+		leaked[info.Handle] = true
+		mu.Unlock()
+	})
+	defer SetLeakHandler(nil)
+
+	geom, _ := CreateFromWKT("POLYGON ((0 0,1 0,1 1,0 1,0 0))", SpatialReference{})
+	handle := uintptr(unsafe.Pointer(geom.cval))
+	// mg is not used after Use, which must keep it alive during the call
+	mg := ManageGeometry(geom)
+	assert.NoError(t, mg.Use(func(geom Geometry) error {
+		for i := 0; i < 10; i++ {
+			runtime.GC()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			released := leaked[handle]
+			mu.Unlock()
+			if !assert.False(t, released, "geometry released during Use") {
+				return nil
+			}
+			assert.Equal(t, 1.0, geom.Area())
+		}
+		return nil
+	}))
+}
@@ -44,3 +44,28 @@ func (gfd GeometryFieldDefinition) IsNull() bool {
 func (ct CoordinateTransform) IsNull() bool {
 	return ct.cval == nil
 }
+
+// Check if the field domain is null
+func (domain FieldDomain) IsNull() bool {
+	return domain.cval == nil
+}
+
+// Check if the prepared geometry is null
+func (pg PreparedGeometry) IsNull() bool {
+	return pg.cval == nil
+}
+
+// Check if the style manager is null
+func (sm StyleManager) IsNull() bool {
+	return sm.cval == nil
+}
+
+// Check if the style tool is null
+func (st StyleTool) IsNull() bool {
+	return st.cval == nil
+}
+
+// Check if the style table is null
+func (table StyleTable) IsNull() bool {
+	return table.cval == nil
+}
@@ -2,11 +2,14 @@ package gdal
 
 /*
 #include "go_gdal.h"
+#include "go_ogr.h"
 #include "go_ogr_wkb.h"
 #include "gdal_version.h"
 */
 import "C"
 import (
+	"fmt"
+	"math"
 	"reflect"
 	"time"
 	"unsafe"
@@ -43,6 +46,134 @@ const (
 	GT_GeometryCollection25D = GeometryType(C.wkbGeometryCollection25D)
 )
 
+// Curve and surface geometry types, and the ISO Z, M and ZM variants of all
+// geometry types.  The Z variants of the simple feature types above are the
+// 25D types.
+const (
+	GT_CircularString    = GeometryType(C.wkbCircularString)
+	GT_CompoundCurve     = GeometryType(C.wkbCompoundCurve)
+	GT_CurvePolygon      = GeometryType(C.wkbCurvePolygon)
+	GT_MultiCurve        = GeometryType(C.wkbMultiCurve)
+	GT_MultiSurface      = GeometryType(C.wkbMultiSurface)
+	GT_Curve             = GeometryType(C.wkbCurve)
+	GT_Surface           = GeometryType(C.wkbSurface)
+	GT_PolyhedralSurface = GeometryType(C.wkbPolyhedralSurface)
+	GT_TIN               = GeometryType(C.wkbTIN)
+	GT_Triangle          = GeometryType(C.wkbTriangle)
+
+	GT_CircularStringZ    = GeometryType(C.wkbCircularStringZ)
+	GT_CompoundCurveZ     = GeometryType(C.wkbCompoundCurveZ)
+	GT_CurvePolygonZ      = GeometryType(C.wkbCurvePolygonZ)
+	GT_MultiCurveZ        = GeometryType(C.wkbMultiCurveZ)
+	GT_MultiSurfaceZ      = GeometryType(C.wkbMultiSurfaceZ)
+	GT_CurveZ             = GeometryType(C.wkbCurveZ)
+	GT_SurfaceZ           = GeometryType(C.wkbSurfaceZ)
+	GT_PolyhedralSurfaceZ = GeometryType(C.wkbPolyhedralSurfaceZ)
+	GT_TINZ               = GeometryType(C.wkbTINZ)
+	GT_TriangleZ          = GeometryType(C.wkbTriangleZ)
+
+	GT_PointM              = GeometryType(C.wkbPointM)
+	GT_LineStringM         = GeometryType(C.wkbLineStringM)
+	GT_PolygonM            = GeometryType(C.wkbPolygonM)
+	GT_MultiPointM         = GeometryType(C.wkbMultiPointM)
+	GT_MultiLineStringM    = GeometryType(C.wkbMultiLineStringM)
+	GT_MultiPolygonM       = GeometryType(C.wkbMultiPolygonM)
+	GT_GeometryCollectionM = GeometryType(C.wkbGeometryCollectionM)
+	GT_CircularStringM     = GeometryType(C.wkbCircularStringM)
+	GT_CompoundCurveM      = GeometryType(C.wkbCompoundCurveM)
+	GT_CurvePolygonM       = GeometryType(C.wkbCurvePolygonM)
+	GT_MultiCurveM         = GeometryType(C.wkbMultiCurveM)
+	GT_MultiSurfaceM       = GeometryType(C.wkbMultiSurfaceM)
+	GT_CurveM              = GeometryType(C.wkbCurveM)
+	GT_SurfaceM            = GeometryType(C.wkbSurfaceM)
+	GT_PolyhedralSurfaceM  = GeometryType(C.wkbPolyhedralSurfaceM)
+	GT_TINM                = GeometryType(C.wkbTINM)
+	GT_TriangleM           = GeometryType(C.wkbTriangleM)
+
+	GT_PointZM              = GeometryType(C.wkbPointZM)
+	GT_LineStringZM         = GeometryType(C.wkbLineStringZM)
+	GT_PolygonZM            = GeometryType(C.wkbPolygonZM)
+	GT_MultiPointZM         = GeometryType(C.wkbMultiPointZM)
+	GT_MultiLineStringZM    = GeometryType(C.wkbMultiLineStringZM)
+	GT_MultiPolygonZM       = GeometryType(C.wkbMultiPolygonZM)
+	GT_GeometryCollectionZM = GeometryType(C.wkbGeometryCollectionZM)
+	GT_CircularStringZM     = GeometryType(C.wkbCircularStringZM)
+	GT_CompoundCurveZM      = GeometryType(C.wkbCompoundCurveZM)
+	GT_CurvePolygonZM       = GeometryType(C.wkbCurvePolygonZM)
+	GT_MultiCurveZM         = GeometryType(C.wkbMultiCurveZM)
+	GT_MultiSurfaceZM       = GeometryType(C.wkbMultiSurfaceZM)
+	GT_CurveZM              = GeometryType(C.wkbCurveZM)
+	GT_SurfaceZM            = GeometryType(C.wkbSurfaceZM)
+	GT_PolyhedralSurfaceZM  = GeometryType(C.wkbPolyhedralSurfaceZM)
+	GT_TINZM                = GeometryType(C.wkbTINZM)
+	GT_TriangleZM           = GeometryType(C.wkbTriangleZM)
+)
+
+// Fetch the name of the geometry type
+func (gt GeometryType) Name() string {
+	name := C.OGRGeometryTypeToName(C.OGRwkbGeometryType(gt))
+	return C.GoString(name)
+}
+
+// Test if the geometry type has a z ordinate
+func (gt GeometryType) HasZ() bool {
+	return C.OGR_GT_HasZ(C.OGRwkbGeometryType(gt)) != 0
+}
+
+// Test if the geometry type has an m ordinate
+func (gt GeometryType) HasM() bool {
+	return C.OGR_GT_HasM(C.OGRwkbGeometryType(gt)) != 0
+}
+
+// Return the 2D variant of the geometry type
+func (gt GeometryType) Flatten() GeometryType {
+	return GeometryType(C.OGR_GT_Flatten(C.OGRwkbGeometryType(gt)))
+}
+
+// Return the variant of the geometry type with or without z and m
+// ordinates
+func (gt GeometryType) SetModifier(hasZ, hasM bool) GeometryType {
+	return GeometryType(C.OGR_GT_SetModifier(C.OGRwkbGeometryType(gt), BoolToCInt(hasZ), BoolToCInt(hasM)))
+}
+
+// Test if the geometry type is a curve, such as a line string or a
+// circular string
+func (gt GeometryType) IsCurve() bool {
+	return C.OGR_GT_IsCurve(C.OGRwkbGeometryType(gt)) != 0
+}
+
+// Test if the geometry type is a surface, such as a polygon or a curve
+// polygon
+func (gt GeometryType) IsSurface() bool {
+	return C.OGR_GT_IsSurface(C.OGRwkbGeometryType(gt)) != 0
+}
+
+// Test if the geometry type is or may contain a non-linear geometry
+func (gt GeometryType) IsNonLinear() bool {
+	return C.OGR_GT_IsNonLinear(C.OGRwkbGeometryType(gt)) != 0
+}
+
+// Test if the geometry type is the same as, or a subclass of, the super
+// type
+func (gt GeometryType) IsSubClassOf(super GeometryType) bool {
+	return C.OGR_GT_IsSubClassOf(C.OGRwkbGeometryType(gt), C.OGRwkbGeometryType(super)) != 0
+}
+
+// Return the collection type that can hold the geometry type
+func (gt GeometryType) Collection() GeometryType {
+	return GeometryType(C.OGR_GT_GetCollection(C.OGRwkbGeometryType(gt)))
+}
+
+// Return the curve variant of a linear geometry type
+func (gt GeometryType) Curve() GeometryType {
+	return GeometryType(C.OGR_GT_GetCurve(C.OGRwkbGeometryType(gt)))
+}
+
+// Return the linear variant of a curve geometry type
+func (gt GeometryType) Linear() GeometryType {
+	return GeometryType(C.OGR_GT_GetLinear(C.OGRwkbGeometryType(gt)))
+}
+
 /* -------------------------------------------------------------------- */
 /*      Envelope functions                                              */
 /* -------------------------------------------------------------------- */
@@ -344,6 +475,58 @@ func CreateFromJson(_json string) Geometry {
 	return newGeom
 }
 
+// Create a geometry object from its well known binary representation,
+// reporting the GDAL error message on failure
+func ParseWKB(wkb []uint8, srs SpatialReference) (Geometry, error) {
+	if len(wkb) == 0 {
+		return Geometry{}, fmt.Errorf("%s: empty WKB", ErrCorruptData)
+	}
+	C.CPLErrorReset()
+	geom, err := CreateFromWKB(wkb, srs, len(wkb))
+	return geom, parseError(err)
+}
+
+// Create a geometry object from its well known text representation,
+// reporting the GDAL error message on failure
+func ParseWKT(wkt string, srs SpatialReference) (Geometry, error) {
+	C.CPLErrorReset()
+	geom, err := CreateFromWKT(wkt, srs)
+	return geom, parseError(err)
+}
+
+// Create a geometry object from its GeoJSON representation, reporting the
+// GDAL error message on failure
+func ParseJSON(_json string) (Geometry, error) {
+	C.CPLErrorReset()
+	geom := CreateFromJson(_json)
+	if geom.cval == nil {
+		return geom, parseError(ErrCorruptData)
+	}
+	return geom, nil
+}
+
+// Create a geometry object from its GML representation, reporting the
+// GDAL error message on failure
+func ParseGML(gml string) (Geometry, error) {
+	C.CPLErrorReset()
+	geom := CreateFromGML(gml)
+	if geom.cval == nil {
+		return geom, parseError(ErrCorruptData)
+	}
+	return geom, nil
+}
+
+// Add the last GDAL error message, if any, to a parse error
+func parseError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := C.GoString(C.CPLGetLastErrorMsg()); msg != "" {
+		return fmt.Errorf("%s: %s", err, msg)
+	}
+	return err
+}
+
 // Destroy geometry object
 func (geometry Geometry) Destroy() {
 	C.OGR_G_DestroyGeometry(geometry.cval)
@@ -402,6 +585,71 @@ func (geom Geometry) ForceToMultiLineString() Geometry {
 	return Geometry{newGeom}
 }
 
+// Convert to another geometry type, such as a curve polygon to a polygon
+// or a multi line string to a multi curve.  The geometry is consumed and
+// the converted geometry returned; it is unchanged if no conversion is
+// possible.
+func (geom Geometry) ForceTo(geomType GeometryType, options []string) Geometry {
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	newGeom := C.OGR_G_ForceTo(
+		geom.cval,
+		C.OGRwkbGeometryType(geomType),
+		(**C.char)(unsafe.Pointer(&opts[0])),
+	)
+	return Geometry{newGeom}
+}
+
+// Test if the geometry has curves, or, if lookForNonLinear is set, has
+// curves that are not simple line segments
+func (geom Geometry) HasCurveGeometry(lookForNonLinear bool) bool {
+	val := C.OGR_G_HasCurveGeometry(geom.cval, BoolToCInt(lookForNonLinear))
+	return val != 0
+}
+
+// Return a linear approximation of the geometry, with arcs stepped every
+// maxAngle degrees, or by a default step if maxAngle is 0
+func (geom Geometry) GetLinearGeometry(maxAngle float64, options []string) Geometry {
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	newGeom := C.OGR_G_GetLinearGeometry(
+		geom.cval,
+		C.double(maxAngle),
+		(**C.char)(unsafe.Pointer(&opts[0])),
+	)
+	return Geometry{newGeom}
+}
+
+// Return a curve version of the geometry, with arcs recovered from linear
+// approximations where possible
+func (geom Geometry) GetCurveGeometry(options []string) Geometry {
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	newGeom := C.OGR_G_GetCurveGeometry(
+		geom.cval,
+		(**C.char)(unsafe.Pointer(&opts[0])),
+	)
+	return Geometry{newGeom}
+}
+
 // Get the dimension of this geometry
 func (geom Geometry) Dimension() int {
 	dim := C.OGR_G_GetDimension(geom.cval)
@@ -419,6 +667,28 @@ func (geom Geometry) SetCoordinateDimension(dim int) {
 	C.OGR_G_SetCoordinateDimension(geom.cval, C.int(dim))
 }
 
+// Test if the geometry has z coordinates
+func (geom Geometry) Is3D() bool {
+	val := C.OGR_G_Is3D(geom.cval)
+	return val != 0
+}
+
+// Test if the geometry has m coordinates
+func (geom Geometry) IsMeasured() bool {
+	val := C.OGR_G_IsMeasured(geom.cval)
+	return val != 0
+}
+
+// Add or remove the z coordinates of the geometry
+func (geom Geometry) Set3D(is3D bool) {
+	C.OGR_G_Set3D(geom.cval, BoolToCInt(is3D))
+}
+
+// Add or remove the m coordinates of the geometry
+func (geom Geometry) SetMeasured(isMeasured bool) {
+	C.OGR_G_SetMeasured(geom.cval, BoolToCInt(isMeasured))
+}
+
 // Create a copy of this geometry
 func (geom Geometry) Clone() Geometry {
 	newGeom := C.OGR_G_Clone(geom.cval)
@@ -455,6 +725,15 @@ func (geom Geometry) ToWKB() ([]uint8, error) {
 	return b, err
 }
 
+// Convert a geometry to ISO well known binary data, which keeps M values
+func (geom Geometry) ToISOWKB() ([]uint8, error) {
+	b := make([]uint8, geom.WKBSize())
+	cString := (*C.uchar)(unsafe.Pointer(&b[0]))
+	cErr := C.go_ExportToIsoWkb(geom.cval, C.OGRwkbByteOrder(C.wkbNDR), cString)
+	err := OGRErrContainer{ErrVal: cErr}.Err()
+	return b, err
+}
+
 // Returns size of related binary representation
 func (geom Geometry) WKBSize() int {
 	size := C.OGR_G_WkbSize(geom.cval)
@@ -479,6 +758,16 @@ func (geom Geometry) ToWKT() (string, error) {
 	return wkt, err
 }
 
+// Fetch geometry as ISO WKT, which keeps M values
+func (geom Geometry) ToISOWKT() (string, error) {
+	var p *C.char
+	cErr := C.OGR_G_ExportToIsoWkt(geom.cval, &p)
+	err := OGRErrContainer{ErrVal: cErr}.Err()
+	wkt := C.GoString(p)
+	defer C.CPLFree(unsafe.Pointer(p))
+	return wkt, err
+}
+
 // Fetch geometry type
 func (geom Geometry) Type() GeometryType {
 	gt := C.OGR_G_GetGeometryType(geom.cval)
@@ -599,6 +888,264 @@ func (geom Geometry) Segmentize(distance float64) {
 	C.OGR_G_Segmentize(geom.cval, C.double(distance))
 }
 
+// Modify the geometry such that no segment is longer than maxLength
+// meters along the geodesic between its ends.  Added vertices lie on the
+// geodesic on the ellipsoid of the spatial reference, computed with
+// Vincenty's formulae, with z and m values interpolated linearly.  The
+// geometry must have a geographic spatial reference, and an error is
+// returned for segments whose ends are nearly antipodal.
+func (geom Geometry) SegmentizeGeodesic(maxLength float64) error {
+	if maxLength <= 0 {
+		return fmt.Errorf("segment length must be positive, got %g", maxLength)
+	}
+	sr := geom.SpatialReference()
+	if sr.cval == nil || !sr.IsGeographic() {
+		return fmt.Errorf("%s: geodesic segmentation requires a geographic spatial reference", ErrUnsupportedOperation)
+	}
+	semiMajor, err := sr.SemiMajorAxis()
+	if err != nil {
+		return err
+	}
+	semiMinor, err := sr.SemiMinorAxis()
+	if err != nil {
+		return err
+	}
+	_, toRadians := sr.AngularUnits()
+
+	// data coordinates are in latitude, longitude order if the axis
+	// mapping keeps an authority order that is itself latitude first
+	var count C.int
+	mapping := C.OSRGetDataAxisToSRSAxisMapping(sr.cval, &count)
+	swapped := count > 0 && *mapping == 2
+	latFirst := sr.EPSGTreatsAsLatLong() != swapped
+
+	seg := geodesicSegmentizer{
+		ellipsoid: ellipsoid{a: semiMajor, f: (semiMajor - semiMinor) / semiMajor},
+		maxLength: maxLength,
+		toRadians: toRadians,
+		latFirst:  latFirst,
+	}
+	return seg.segmentize(geom)
+}
+
+type geodesicSegmentizer struct {
+	ellipsoid ellipsoid
+	maxLength float64
+	toRadians float64
+	latFirst  bool
+}
+
+func (seg geodesicSegmentizer) segmentize(geom Geometry) error {
+	if count := geom.GeometryCount(); count > 0 {
+		for i := 0; i < count; i++ {
+			if err := seg.segmentize(geom.Geometry(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if geom.Type().Flatten() != GT_LineString && geom.Type().Flatten() != GT_LinearRing {
+		return nil
+	}
+
+	xs, ys, zs, ms := geom.pointsZM(geom.Is3D(), geom.IsMeasured())
+	if len(xs) < 2 {
+		return nil
+	}
+	var outX, outY, outZ, outM []float64
+	add := func(i int) {
+		outX = append(outX, xs[i])
+		outY = append(outY, ys[i])
+		if zs != nil {
+			outZ = append(outZ, zs[i])
+		}
+		if ms != nil {
+			outM = append(outM, ms[i])
+		}
+	}
+	add(0)
+	for i := 1; i < len(xs); i++ {
+		lon1, lat1 := seg.lonLat(xs[i-1], ys[i-1])
+		lon2, lat2 := seg.lonLat(xs[i], ys[i])
+		length, azimuth, ok := seg.ellipsoid.inverse(lon1, lat1, lon2, lat2)
+		if !ok {
+			return fmt.Errorf(
+				"%s: no geodesic found between (%g %g) and (%g %g)",
+				ErrIllegal, xs[i-1], ys[i-1], xs[i], ys[i],
+			)
+		}
+		steps := int(math.Ceil(length / seg.maxLength))
+		for step := 1; step < steps; step++ {
+			f := float64(step) / float64(steps)
+			lon, lat := seg.ellipsoid.direct(lon1, lat1, azimuth, f*length)
+			// keep longitudes continuous with the segment ends
+			for lon-lon1 > math.Pi {
+				lon -= 2 * math.Pi
+			}
+			for lon-lon1 < -math.Pi {
+				lon += 2 * math.Pi
+			}
+			x, y := seg.dataXY(lon, lat)
+			outX = append(outX, x)
+			outY = append(outY, y)
+			if zs != nil {
+				outZ = append(outZ, zs[i-1]+f*(zs[i]-zs[i-1]))
+			}
+			if ms != nil {
+				outM = append(outM, ms[i-1]+f*(ms[i]-ms[i-1]))
+			}
+		}
+		add(i)
+	}
+	return geom.SetPointsZM(outX, outY, outZ, outM)
+}
+
+// Convert data coordinates to longitude and latitude in radians
+func (seg geodesicSegmentizer) lonLat(x, y float64) (float64, float64) {
+	if seg.latFirst {
+		x, y = y, x
+	}
+	return x * seg.toRadians, y * seg.toRadians
+}
+
+// Convert longitude and latitude in radians to data coordinates
+func (seg geodesicSegmentizer) dataXY(lon, lat float64) (float64, float64) {
+	x, y := lon/seg.toRadians, lat/seg.toRadians
+	if seg.latFirst {
+		return y, x
+	}
+	return x, y
+}
+
+// ellipsoid holds the semi-major axis and flattening of an ellipsoid of
+// revolution, for geodesic computations with Vincenty's formulae
+type ellipsoid struct {
+	a, f float64
+}
+
+// Solve the inverse geodesic problem between two points given in radians,
+// returning the length of the geodesic and its azimuth at the first point.
+// ok is false if the iteration does not converge, which happens for
+// nearly antipodal points.
+func (e ellipsoid) inverse(lon1, lat1, lon2, lat2 float64) (s, azimuth float64, ok bool) {
+	b := e.a * (1 - e.f)
+	sinU1, cosU1 := math.Sincos(math.Atan((1 - e.f) * math.Tan(lat1)))
+	sinU2, cosU2 := math.Sincos(math.Atan((1 - e.f) * math.Tan(lat2)))
+	l := lon2 - lon1
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM, sinLambda, cosLambda float64
+	for i := 0; ; i++ {
+		if i == 200 {
+			return 0, 0, false
+		}
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident points
+			return 0, 0, true
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		c := e.f / 16 * cosSqAlpha * (4 + e.f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*e.f*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			break
+		}
+	}
+
+	uSq := cosSqAlpha * (e.a*e.a - b*b) / (b * b)
+	bigA, bigB := vincentyCoefficients(uSq)
+	deltaSigma := vincentyDeltaSigma(bigB, sinSigma, cosSigma, cos2SigmaM)
+	s = b * bigA * (sigma - deltaSigma)
+	azimuth = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	return s, azimuth, true
+}
+
+// Solve the direct geodesic problem: return the point reached from a point
+// given in radians by following the geodesic with the given azimuth for a
+// length of s
+func (e ellipsoid) direct(lon1, lat1, azimuth, s float64) (lon2, lat2 float64) {
+	b := e.a * (1 - e.f)
+	sinU1, cosU1 := math.Sincos(math.Atan((1 - e.f) * math.Tan(lat1)))
+	sinAlpha1, cosAlpha1 := math.Sincos(azimuth)
+	sigma1 := math.Atan2(sinU1, cosU1*cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cosSqAlpha := 1 - sinAlpha*sinAlpha
+	uSq := cosSqAlpha * (e.a*e.a - b*b) / (b * b)
+	bigA, bigB := vincentyCoefficients(uSq)
+
+	sigma := s / (b * bigA)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < 200; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		prev := sigma
+		sigma = s/(b*bigA) + vincentyDeltaSigma(bigB, sinSigma, cosSigma, cos2SigmaM)
+		if math.Abs(sigma-prev) < 1e-12 {
+			break
+		}
+	}
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sincos(sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 = math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-e.f)*math.Hypot(sinAlpha, x))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	c := e.f / 16 * cosSqAlpha * (4 + e.f*(4-3*cosSqAlpha))
+	l := lambda - (1-c)*e.f*sinAlpha*
+		(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+	return lon1 + l, lat2
+}
+
+func vincentyCoefficients(uSq float64) (a, b float64) {
+	a = 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b = uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	return a, b
+}
+
+func vincentyDeltaSigma(b, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	return b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+}
+
+// Swap the x and y coordinates of all the points of the geometry
+func (geom Geometry) SwapXY() {
+	C.OGR_G_SwapXY(geom.cval)
+}
+
+// PrecisionFlags control how SetPrecision snaps geometries to a grid
+type PrecisionFlags int
+
+const (
+	// Snap vertices only, without preserving a valid topology
+	PrecisionNoTopology = PrecisionFlags(1)
+	// Keep components that collapse to a lower dimension
+	PrecisionKeepCollapsed = PrecisionFlags(2)
+)
+
+// Return a copy of the geometry with its vertices snapped to a grid of
+// the given size, repairing the topology unless PrecisionNoTopology is
+// set.  Requires GDAL 3.9 and GEOS 3.6 or later.
+func (geom Geometry) SetPrecision(gridSize float64, flags PrecisionFlags) (Geometry, error) {
+	if VERSION_NUM < 3090000 {
+		return Geometry{}, fmt.Errorf("%s: setting precision requires GDAL 3.9 or later", ErrUnsupportedOperation)
+	}
+	if !geosAtLeast(3, 6) {
+		return Geometry{}, fmt.Errorf("%s: setting precision requires GEOS 3.6 or later", ErrUnsupportedOperation)
+	}
+	C.CPLErrorReset()
+	return geosResult(C.go_G_SetPrecision(geom.cval, C.double(gridSize), C.int(flags)))
+}
+
 // Return true if these features intersect
 func (geom Geometry) Intersects(other Geometry) bool {
 	val := C.OGR_G_Intersects(geom.cval, other.cval)
@@ -665,6 +1212,89 @@ func (geom Geometry) Buffer(distance float64, segments int) Geometry {
 	return Geometry{newGeom}
 }
 
+// BufferEndCapStyle selects the shape of buffers at the ends of lines
+type BufferEndCapStyle int
+
+const (
+	BufferEndCapRound = BufferEndCapStyle(iota)
+	BufferEndCapFlat
+	BufferEndCapSquare
+)
+
+// BufferJoinStyle selects the shape of buffers at line corners
+type BufferJoinStyle int
+
+const (
+	BufferJoinRound = BufferJoinStyle(iota)
+	BufferJoinMitre
+	BufferJoinBevel
+)
+
+// Options for BufferEx.  The zero value gives round caps and joins with
+// GDAL's default number of segments.
+type BufferOptions struct {
+	EndCapStyle BufferEndCapStyle
+	JoinStyle   BufferJoinStyle
+	// Limit on the ratio of the mitre length to the buffer distance,
+	// beyond which mitre joins are beveled; 0 for the default
+	MitreLimit float64
+	// Number of segments used to approximate a quarter circle; 0 for the
+	// default
+	QuadrantSegments int
+	// Buffer lines on one side only: the left for positive distances, the
+	// right for negative ones
+	SingleSided bool
+}
+
+func (opts BufferOptions) list() []string {
+	var list []string
+	switch opts.EndCapStyle {
+	case BufferEndCapFlat:
+		list = append(list, "ENDCAP_STYLE=FLAT")
+	case BufferEndCapSquare:
+		list = append(list, "ENDCAP_STYLE=SQUARE")
+	default:
+		list = append(list, "ENDCAP_STYLE=ROUND")
+	}
+	switch opts.JoinStyle {
+	case BufferJoinMitre:
+		list = append(list, "JOIN_STYLE=MITRE")
+	case BufferJoinBevel:
+		list = append(list, "JOIN_STYLE=BEVEL")
+	default:
+		list = append(list, "JOIN_STYLE=ROUND")
+	}
+	if opts.MitreLimit > 0 {
+		list = append(list, fmt.Sprintf("MITRE_LIMIT=%g", opts.MitreLimit))
+	}
+	if opts.QuadrantSegments > 0 {
+		list = append(list, fmt.Sprintf("QUADRANT_SEGMENTS=%d", opts.QuadrantSegments))
+	}
+	if opts.SingleSided {
+		list = append(list, "SINGLE_SIDED=YES")
+	}
+	return list
+}
+
+// Compute buffer of the geometry with control over its end caps and
+// joins.  Requires GDAL 3.10 or later.
+func (geom Geometry) BufferEx(distance float64, options BufferOptions) (Geometry, error) {
+	if VERSION_NUM < 3100000 {
+		return Geometry{}, fmt.Errorf("%s: buffer options require GDAL 3.10 or later", ErrUnsupportedOperation)
+	}
+	list := options.list()
+	length := len(list)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(list[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	C.CPLErrorReset()
+	return geosResult(C.go_G_BufferEx(geom.cval, C.double(distance), (**C.char)(unsafe.Pointer(&opts[0]))))
+}
+
 // Compute intersection of this geometry with the other
 func (geom Geometry) Intersection(other Geometry) Geometry {
 	newGeom := C.OGR_G_Intersection(geom.cval, other.cval)
@@ -682,12 +1312,123 @@ func (geom Geometry) UnionCascaded() Geometry {
 	return Geometry{newGeom}
 }
 
-// Unimplemented: PointOn Surface (until 2.0)
+// Compute the union of all the components of the geometry, merging
+// overlapping polygons and noding line work.  Requires GDAL 3.7 or later.
+func (geom Geometry) UnaryUnion() (Geometry, error) {
+	if VERSION_NUM < 3070000 {
+		return Geometry{}, fmt.Errorf("%s: unary union requires GDAL 3.7 or later", ErrUnsupportedOperation)
+	}
+	C.CPLErrorReset()
+	return geosResult(C.go_G_UnaryUnion(geom.cval))
+}
+
 // Return a point guaranteed to lie on the surface
-// func (geom Geometry) PointOnSurface() Geometry {
-//  newGeom := C.OGR_G_PointOnSurface(geom.cval)
-//  return Geometry{newGeom}
-// }
+func (geom Geometry) PointOnSurface() Geometry {
+	newGeom := C.OGR_G_PointOnSurface(geom.cval)
+	return Geometry{newGeom}
+}
+
+// Repair an invalid geometry without losing vertices, returning a valid
+// copy.  Options are passed to the repair algorithm, for instance
+// METHOD=STRUCTURE to rebuild polygons from their rings rather than from
+// their line work, and KEEP_COLLAPSED=YES to keep components that collapse
+// to a lower dimension.  Requires GDAL 3.0 and GEOS 3.8 or later, and GDAL
+// 3.4 or later for options.
+func (geom Geometry) MakeValid(options []string) (Geometry, error) {
+	if VERSION_NUM < 3000000 {
+		return Geometry{}, fmt.Errorf("%s: making geometries valid requires GDAL 3.0 or later", ErrUnsupportedOperation)
+	}
+	if !geosAtLeast(3, 8) {
+		return Geometry{}, fmt.Errorf("%s: making geometries valid requires GEOS 3.8 or later", ErrUnsupportedOperation)
+	}
+	length := len(options)
+	if length == 0 {
+		C.CPLErrorReset()
+		return geosResult(C.go_G_MakeValid(geom.cval))
+	}
+	if VERSION_NUM < 3040000 {
+		return Geometry{}, fmt.Errorf("%s: make valid options require GDAL 3.4 or later", ErrUnsupportedOperation)
+	}
+
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	C.CPLErrorReset()
+	return geosResult(C.go_G_MakeValidEx(geom.cval, (**C.char)(unsafe.Pointer(&opts[0]))))
+}
+
+// Return the Delaunay triangulation of the vertices of the geometry, as a
+// collection of polygons, or of line strings if onlyEdges is set.  Vertices
+// closer than tolerance are snapped together.
+func (geom Geometry) DelaunayTriangulation(tolerance float64, onlyEdges bool) (Geometry, error) {
+	C.CPLErrorReset()
+	return geosResult(C.OGR_G_DelaunayTriangulation(geom.cval, C.double(tolerance), BoolToCInt(onlyEdges)))
+}
+
+// Compute a concave hull of the geometry.  ratio ranges from 0, the most
+// concave hull, to 1, the convex hull.  Requires GDAL 3.6 and GEOS 3.11 or
+// later.
+func (geom Geometry) ConcaveHull(ratio float64, allowHoles bool) (Geometry, error) {
+	if VERSION_NUM < 3060000 {
+		return Geometry{}, fmt.Errorf("%s: concave hulls require GDAL 3.6 or later", ErrUnsupportedOperation)
+	}
+	if !geosAtLeast(3, 11) {
+		return Geometry{}, fmt.Errorf("%s: concave hulls require GEOS 3.11 or later", ErrUnsupportedOperation)
+	}
+	C.CPLErrorReset()
+	return geosResult(C.go_G_ConcaveHull(geom.cval, C.double(ratio), BoolToCInt(allowHoles)))
+}
+
+// Return a copy of the geometry in normal form, with rings, vertices and
+// components in a canonical order, so that equal geometries compare equal
+// by their WKT.  Requires GDAL 3.3 or later.
+func (geom Geometry) Normalize() (Geometry, error) {
+	if VERSION_NUM < 3030000 {
+		return Geometry{}, fmt.Errorf("%s: normalizing geometries requires GDAL 3.3 or later", ErrUnsupportedOperation)
+	}
+	C.CPLErrorReset()
+	return geosResult(C.go_G_Normalize(geom.cval))
+}
+
+// Fetch the version of GEOS that GDAL is built against.  ok is false if
+// GDAL is built without GEOS, or is older than 3.4 and cannot report it.
+func GEOSVersion() (major, minor, patch int, ok bool) {
+	var cMajor, cMinor, cPatch C.int
+	if C.go_GetGEOSVersion(&cMajor, &cMinor, &cPatch) != 1 {
+		return 0, 0, 0, false
+	}
+	return int(cMajor), int(cMinor), int(cPatch), true
+}
+
+// Test if GDAL is built against GEOS major.minor or later.  GDAL older than
+// 3.4 cannot report the GEOS version, and passes the test.
+func geosAtLeast(major, minor int) bool {
+	if VERSION_NUM < 3040000 {
+		return true
+	}
+	geosMajor, geosMinor, _, ok := GEOSVersion()
+	if !ok {
+		return false
+	}
+	return geosMajor > major || geosMajor == major && geosMinor >= minor
+}
+
+// Wrap the result of a GEOS backed operation, which is NULL if GDAL is
+// built without GEOS or the operation fails
+func geosResult(newGeom C.OGRGeometryH) (Geometry, error) {
+	if newGeom == nil {
+		msg := C.GoString(C.CPLGetLastErrorMsg())
+		if msg == "" {
+			msg = "geometry operation failed"
+		}
+		return Geometry{}, fmt.Errorf("Error: %s", msg)
+	}
+	return Geometry{newGeom}, nil
+}
 
 // Compute difference between this geometry and the other
 func (geom Geometry) Difference(other Geometry) Geometry {
@@ -728,6 +1469,35 @@ func (geom Geometry) Area() float64 {
 	return float64(area)
 }
 
+// Compute the area of the geometry on the ellipsoid of its spatial
+// reference, in square meters.  Requires GDAL 3.9 or later.
+func (geom Geometry) GeodesicArea() (float64, error) {
+	if VERSION_NUM < 3090000 {
+		return 0, fmt.Errorf("%s: geodesic area requires GDAL 3.9 or later", ErrUnsupportedOperation)
+	}
+	C.CPLErrorReset()
+	area := C.go_G_GeodesicArea(geom.cval)
+	if area < 0 {
+		return 0, fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return float64(area), nil
+}
+
+// Compute the length of the geometry, or the perimeter of a surface, on
+// the ellipsoid of its spatial reference, in meters.  Requires GDAL 3.10
+// or later.
+func (geom Geometry) GeodesicLength() (float64, error) {
+	if VERSION_NUM < 3100000 {
+		return 0, fmt.Errorf("%s: geodesic length requires GDAL 3.10 or later", ErrUnsupportedOperation)
+	}
+	C.CPLErrorReset()
+	length := C.go_G_GeodesicLength(geom.cval)
+	if length < 0 {
+		return 0, fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return float64(length), nil
+}
+
 // Compute centroid of geometry
 func (geom Geometry) Centroid() Geometry {
 	centroid := Geometry{C.OGR_G_CreateGeometry(C.wkbPoint)}
@@ -770,13 +1540,220 @@ func (geom Geometry) Polygonize() Geometry {
 	return Geometry{newGeom}
 }
 
+// Polygonize a set of noded edges, also returning the dangles: the edges
+// that cannot be part of a polygon because one of their ends, once other
+// dangles are removed, touches no other edge.  Dangles are returned as a
+// multi line string of copies of the input edges.  GDAL does not expose
+// the dangles found by GEOS, so they are computed here by repeatedly
+// pruning the edges that end at a node of degree 1, which approximates
+// GEOS: nodes are matched on exact 2D end points, and cut edges, which
+// join two polygons or a polygon and a dangle, are neither polygonized nor
+// reported.  polygons is null if GDAL is built without GEOS.
+func (geom Geometry) PolygonizeWithDangles() (polygons, dangles Geometry) {
+	polygons = geom.Polygonize()
+
+	var edges []Geometry
+	if geom.Type().Flatten() == GT_LineString {
+		edges = append(edges, geom)
+	} else {
+		for i := 0; i < geom.GeometryCount(); i++ {
+			edge := geom.Geometry(i)
+			if edge.Type().Flatten() == GT_LineString {
+				edges = append(edges, edge)
+			}
+		}
+	}
+
+	type node [2]float64
+	ends := make([][2]node, len(edges))
+	incident := make(map[node][]int)
+	degree := make(map[node]int)
+	for i, edge := range edges {
+		n := edge.PointCount()
+		if n < 2 {
+			continue
+		}
+		ends[i] = [2]node{{edge.X(0), edge.Y(0)}, {edge.X(n - 1), edge.Y(n - 1)}}
+		for _, end := range ends[i] {
+			incident[end] = append(incident[end], i)
+			degree[end]++
+		}
+	}
+
+	// repeatedly remove the edges ending at a node of degree 1, as
+	// removing them can leave further nodes of degree 1
+	var pending []node
+	for _, end := range ends {
+		for _, n := range end {
+			if degree[n] == 1 {
+				pending = append(pending, n)
+			}
+		}
+	}
+	removed := make([]bool, len(edges))
+	dangles = Create(GT_MultiLineString)
+	for len(pending) > 0 {
+		end := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for _, i := range incident[end] {
+			if removed[i] {
+				continue
+			}
+			removed[i] = true
+			dangles.AddGeometry(edges[i])
+			for _, other := range ends[i] {
+				degree[other]--
+				if degree[other] == 1 {
+					pending = append(pending, other)
+				}
+			}
+		}
+	}
+	return polygons, dangles
+}
+
 // Fetch number of points in the geometry
 func (geom Geometry) PointCount() int {
 	count := C.OGR_G_GetPointCount(geom.cval)
 	return int(count)
 }
 
-// Unimplemented: Points
+// Fetch all the points of a point or line string at once.  zs is nil if
+// the geometry has no z coordinates.
+func (geom Geometry) Points() (xs, ys, zs []float64) {
+	xs, ys, zs, _ = geom.pointsZM(geom.Is3D(), false)
+	return
+}
+
+// Fetch all the points of a point or line string at once, including M.  zs
+// and ms are nil if the geometry has no z or m coordinates.
+func (geom Geometry) PointsZM() (xs, ys, zs, ms []float64) {
+	return geom.pointsZM(geom.Is3D(), geom.IsMeasured())
+}
+
+func (geom Geometry) pointsZM(withZ, withM bool) (xs, ys, zs, ms []float64) {
+	count := geom.PointCount()
+	if count == 0 {
+		return
+	}
+	xs = make([]float64, count)
+	ys = make([]float64, count)
+	var pz, pm unsafe.Pointer
+	if withZ {
+		zs = make([]float64, count)
+		pz = unsafe.Pointer(&zs[0])
+	}
+	if withM {
+		ms = make([]float64, count)
+		pm = unsafe.Pointer(&ms[0])
+	}
+	C.OGR_G_GetPointsZM(
+		geom.cval,
+		unsafe.Pointer(&xs[0]), 8,
+		unsafe.Pointer(&ys[0]), 8,
+		pz, 8,
+		pm, 8,
+	)
+	return
+}
+
+// Fetch all the points of a point or line string into dst, interleaved as
+// layout.Stride() values per point, and return the filled slice.  dst is
+// reallocated if it is too small.
+func (geom Geometry) PointsInto(dst []float64, layout CoordLayout) []float64 {
+	count := geom.PointCount()
+	stride := layout.Stride()
+	if cap(dst) < count*stride {
+		dst = make([]float64, count*stride)
+	}
+	dst = dst[:count*stride]
+	if count == 0 {
+		return dst
+	}
+
+	var pz, pm unsafe.Pointer
+	if layout.HasZ() {
+		pz = unsafe.Pointer(&dst[2])
+	}
+	if layout.HasM() {
+		pm = unsafe.Pointer(&dst[stride-1])
+	}
+	byteStride := C.int(stride * 8)
+	C.OGR_G_GetPointsZM(
+		geom.cval,
+		unsafe.Pointer(&dst[0]), byteStride,
+		unsafe.Pointer(&dst[1]), byteStride,
+		pz, byteStride,
+		pm, byteStride,
+	)
+	return dst
+}
+
+// Replace all the points of a point or line string at once.  zs may be nil
+// for a 2D geometry.
+func (geom Geometry) SetPoints(xs, ys, zs []float64) error {
+	return geom.SetPointsZM(xs, ys, zs, nil)
+}
+
+// Replace all the points of a point or line string at once, including M.
+// zs or ms may be nil to leave out z or m coordinates.
+func (geom Geometry) SetPointsZM(xs, ys, zs, ms []float64) error {
+	count := len(xs)
+	if len(ys) != count || (zs != nil && len(zs) != count) || (ms != nil && len(ms) != count) {
+		return fmt.Errorf("coordinate slices have different lengths")
+	}
+	if count == 0 {
+		geom.Empty()
+		return nil
+	}
+	var pz, pm unsafe.Pointer
+	if zs != nil {
+		pz = unsafe.Pointer(&zs[0])
+	}
+	if ms != nil {
+		pm = unsafe.Pointer(&ms[0])
+	}
+	C.OGR_G_SetPointsZM(
+		geom.cval,
+		C.int(count),
+		unsafe.Pointer(&xs[0]), 8,
+		unsafe.Pointer(&ys[0]), 8,
+		pz, 8,
+		pm, 8,
+	)
+	return nil
+}
+
+// Replace all the points of a point or line string with coords, interleaved
+// as layout.Stride() values per point
+func (geom Geometry) SetPointsFrom(coords []float64, layout CoordLayout) error {
+	stride := layout.Stride()
+	if len(coords)%stride != 0 {
+		return fmt.Errorf("%d coordinates do not fit a stride of %d", len(coords), stride)
+	}
+	count := len(coords) / stride
+	if count == 0 {
+		geom.Empty()
+		return nil
+	}
+	var pz, pm unsafe.Pointer
+	if layout.HasZ() {
+		pz = unsafe.Pointer(&coords[2])
+	}
+	if layout.HasM() {
+		pm = unsafe.Pointer(&coords[stride-1])
+	}
+	byteStride := C.int(stride * 8)
+	C.OGR_G_SetPointsZM(
+		geom.cval,
+		C.int(count),
+		unsafe.Pointer(&coords[0]), byteStride,
+		unsafe.Pointer(&coords[1]), byteStride,
+		pz, byteStride,
+		pm, byteStride,
+	)
+	return nil
+}
 
 // Fetch the X coordinate of a point in the geometry
 func (geom Geometry) X(index int) float64 {
@@ -796,6 +1773,12 @@ func (geom Geometry) Z(index int) float64 {
 	return float64(z)
 }
 
+// Fetch the M coordinate of a point in the geometry
+func (geom Geometry) M(index int) float64 {
+	m := C.OGR_G_GetM(geom.cval, C.int(index))
+	return float64(m)
+}
+
 // Fetch the coordinates of a point in the geometry
 func (geom Geometry) Point(index int) (x, y, z float64) {
 	C.OGR_G_GetPoint(
@@ -807,6 +1790,18 @@ func (geom Geometry) Point(index int) (x, y, z float64) {
 	return
 }
 
+// Fetch the coordinates of a point in the geometry, including M
+func (geom Geometry) PointZM(index int) (x, y, z, m float64) {
+	C.OGR_G_GetPointZM(
+		geom.cval,
+		C.int(index),
+		(*C.double)(&x),
+		(*C.double)(&y),
+		(*C.double)(&z),
+		(*C.double)(&m))
+	return
+}
+
 // Set the coordinates of a point in the geometry
 func (geom Geometry) SetPoint(index int, x, y, z float64) {
 	C.OGR_G_SetPoint(
@@ -822,6 +1817,28 @@ func (geom Geometry) SetPoint2D(index int, x, y float64) {
 	C.OGR_G_SetPoint_2D(geom.cval, C.int(index), C.double(x), C.double(y))
 }
 
+// Set the coordinates of a point in the geometry, with an M coordinate and
+// no Z coordinate
+func (geom Geometry) SetPointM(index int, x, y, m float64) {
+	C.OGR_G_SetPointM(
+		geom.cval,
+		C.int(index),
+		C.double(x),
+		C.double(y),
+		C.double(m))
+}
+
+// Set the coordinates of a point in the geometry, including M
+func (geom Geometry) SetPointZM(index int, x, y, z, m float64) {
+	C.OGR_G_SetPointZM(
+		geom.cval,
+		C.int(index),
+		C.double(x),
+		C.double(y),
+		C.double(z),
+		C.double(m))
+}
+
 // Add a new point to the geometry (line string or polygon only)
 func (geom Geometry) AddPoint(x, y, z float64) {
 	C.OGR_G_AddPoint(geom.cval, C.double(x), C.double(y), C.double(z))
@@ -832,6 +1849,18 @@ func (geom Geometry) AddPoint2D(x, y float64) {
 	C.OGR_G_AddPoint_2D(geom.cval, C.double(x), C.double(y))
 }
 
+// Add a new point with an M coordinate and no Z coordinate to the geometry
+// (line string or polygon only)
+func (geom Geometry) AddPointM(x, y, m float64) {
+	C.OGR_G_AddPointM(geom.cval, C.double(x), C.double(y), C.double(m))
+}
+
+// Add a new point with Z and M coordinates to the geometry (line string or
+// polygon only)
+func (geom Geometry) AddPointZM(x, y, z, m float64) {
+	C.OGR_G_AddPointZM(geom.cval, C.double(x), C.double(y), C.double(z), C.double(m))
+}
+
 // Fetch the number of elements in the geometry, or number of geometries in the container
 func (geom Geometry) GeometryCount() int {
 	count := C.OGR_G_GetGeometryCount(geom.cval)
@@ -897,6 +1926,18 @@ const (
 	FT_Integer64List = FieldType(C.OFTInteger64List)
 )
 
+// List of field subtypes, which refine the meaning of a field type
+type FieldSubType int
+
+const (
+	FST_None    = FieldSubType(C.OFSTNone)
+	FST_Boolean = FieldSubType(C.OFSTBoolean)
+	FST_Int16   = FieldSubType(C.OFSTInt16)
+	FST_Float32 = FieldSubType(C.OFSTFloat32)
+	FST_JSON    = FieldSubType(C.OFSTJSON)
+	FST_UUID    = FieldSubType(5) // OFSTUUID, new in GDAL 3.5
+)
+
 type Justification int
 
 const (
@@ -1006,25 +2047,103 @@ func (fd FieldDefinition) Set(
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	C.OGR_Fld_Set(
-		fd.cval,
-		cName,
-		C.OGRFieldType(fType),
-		C.int(width),
-		C.int(precision),
-		C.OGRJustification(justify),
-	)
+	C.OGR_Fld_Set(
+		fd.cval,
+		cName,
+		C.OGRFieldType(fType),
+		C.int(width),
+		C.int(precision),
+		C.OGRJustification(justify),
+	)
+}
+
+// Fetch whether this field should be ignored when fetching features
+func (fd FieldDefinition) IsIgnored() bool {
+	ignore := C.OGR_Fld_IsIgnored(fd.cval)
+	return ignore != 0
+}
+
+// Set whether this field should be ignored when fetching features
+func (fd FieldDefinition) SetIgnored(ignore bool) {
+	C.OGR_Fld_SetIgnored(fd.cval, BoolToCInt(ignore))
+}
+
+// Fetch the subtype of this field
+func (fd FieldDefinition) SubType() FieldSubType {
+	subType := C.OGR_Fld_GetSubType(fd.cval)
+	return FieldSubType(subType)
+}
+
+// Set the subtype of this field
+func (fd FieldDefinition) SetSubType(subType FieldSubType) {
+	C.OGR_Fld_SetSubType(fd.cval, C.OGRFieldSubType(subType))
+}
+
+// Fetch whether this field can receive null values
+func (fd FieldDefinition) IsNullable() bool {
+	nullable := C.OGR_Fld_IsNullable(fd.cval)
+	return nullable != 0
+}
+
+// Set whether this field can receive null values
+func (fd FieldDefinition) SetNullable(nullable bool) {
+	C.OGR_Fld_SetNullable(fd.cval, BoolToCInt(nullable))
+}
+
+// Fetch whether this field has a unique constraint
+func (fd FieldDefinition) IsUnique() bool {
+	unique := C.go_Fld_IsUnique(fd.cval)
+	return unique != 0
+}
+
+// Set whether this field has a unique constraint.  Requires GDAL 3.2 or
+// later.
+func (fd FieldDefinition) SetUnique(unique bool) error {
+	if C.go_Fld_SetUnique(fd.cval, BoolToCInt(unique)) == 0 {
+		return fmt.Errorf("%s: unique constraints require GDAL 3.2 or later", ErrUnsupportedOperation)
+	}
+	return nil
+}
+
+// Fetch the default value of this field, as an SQL literal such as 'text',
+// 123 or CURRENT_TIMESTAMP.  It is empty when there is no default.
+func (fd FieldDefinition) Default() string {
+	val := C.OGR_Fld_GetDefault(fd.cval)
+	return C.GoString(val)
+}
+
+// Set the default value of this field, as an SQL literal.  String literals
+// must be quoted, as in 'text'.  An empty value removes the default.
+func (fd FieldDefinition) SetDefault(val string) {
+	if val == "" {
+		C.OGR_Fld_SetDefault(fd.cval, nil)
+		return
+	}
+	cVal := C.CString(val)
+	defer C.free(unsafe.Pointer(cVal))
+	C.OGR_Fld_SetDefault(fd.cval, cVal)
 }
 
-// Fetch whether this field should be ignored when fetching features
-func (fd FieldDefinition) IsIgnored() bool {
-	ignore := C.OGR_Fld_IsIgnored(fd.cval)
-	return ignore != 0
+// Fetch whether the default value of this field is driver specific
+func (fd FieldDefinition) IsDefaultDriverSpecific() bool {
+	specific := C.OGR_Fld_IsDefaultDriverSpecific(fd.cval)
+	return specific != 0
 }
 
-// Set whether this field should be ignored when fetching features
-func (fd FieldDefinition) SetIgnored(ignore bool) {
-	C.OGR_Fld_SetIgnored(fd.cval, BoolToCInt(ignore))
+// Fetch the comment of this field.  Requires GDAL 3.7 or later.
+func (fd FieldDefinition) Comment() string {
+	comment := C.go_Fld_GetComment(fd.cval)
+	return C.GoString(comment)
+}
+
+// Set the comment of this field.  Requires GDAL 3.7 or later.
+func (fd FieldDefinition) SetComment(comment string) error {
+	cComment := C.CString(comment)
+	defer C.free(unsafe.Pointer(cComment))
+	if C.go_Fld_SetComment(fd.cval, cComment) == 0 {
+		return fmt.Errorf("%s: field comments require GDAL 3.7 or later", ErrUnsupportedOperation)
+	}
+	return nil
 }
 
 // Fetch human readable name for the field type
@@ -1033,6 +2152,12 @@ func (ft FieldType) Name() string {
 	return C.GoString(name)
 }
 
+// Fetch human readable name for the field subtype
+func (fst FieldSubType) Name() string {
+	name := C.OGR_GetFieldSubTypeName(C.OGRFieldSubType(fst))
+	return C.GoString(name)
+}
+
 /* -------------------------------------------------------------------- */
 /*      Geometry field definition functions                             */
 /* -------------------------------------------------------------------- */
@@ -1054,11 +2179,95 @@ func (gfd GeometryFieldDefinition) Destroy() {
 	C.OGR_GFld_Destroy(gfd.cval)
 }
 
+// Fetch the name of the geometry field
+func (gfd GeometryFieldDefinition) Name() string {
+	name := C.OGR_GFld_GetNameRef(gfd.cval)
+	return C.GoString(name)
+}
+
+// Set the name of the geometry field
+func (gfd GeometryFieldDefinition) SetName(name string) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.OGR_GFld_SetName(gfd.cval, cName)
+}
+
+// Fetch the geometry type of the geometry field
+func (gfd GeometryFieldDefinition) Type() GeometryType {
+	gt := C.OGR_GFld_GetType(gfd.cval)
+	return GeometryType(gt)
+}
+
+// Set the geometry type of the geometry field
+func (gfd GeometryFieldDefinition) SetType(geomType GeometryType) {
+	C.OGR_GFld_SetType(gfd.cval, C.OGRwkbGeometryType(geomType))
+}
+
+// Fetch the spatial reference of the geometry field definition
+func (gfd GeometryFieldDefinition) SpatialReference() SpatialReference {
+	sr := C.OGR_GFld_GetSpatialRef(gfd.cval)
+	return SpatialReference{sr}
+}
+
 // Set the spatial reference of the geometry field definition
 func (gfd GeometryFieldDefinition) SetSpatialReference(sr SpatialReference) {
 	C.OGR_GFld_SetSpatialRef(gfd.cval, sr.cval)
 }
 
+// Fetch whether the geometry field can receive null values
+func (gfd GeometryFieldDefinition) IsNullable() bool {
+	nullable := C.OGR_GFld_IsNullable(gfd.cval)
+	return nullable != 0
+}
+
+// Set whether the geometry field can receive null values
+func (gfd GeometryFieldDefinition) SetNullable(nullable bool) {
+	C.OGR_GFld_SetNullable(gfd.cval, BoolToCInt(nullable))
+}
+
+// Fetch whether the geometry field should be ignored when fetching features
+func (gfd GeometryFieldDefinition) IsIgnored() bool {
+	ignore := C.OGR_GFld_IsIgnored(gfd.cval)
+	return ignore != 0
+}
+
+// Set whether the geometry field should be ignored when fetching features
+func (gfd GeometryFieldDefinition) SetIgnored(ignore bool) {
+	C.OGR_GFld_SetIgnored(gfd.cval, BoolToCInt(ignore))
+}
+
+// CoordinatePrecision holds the resolution of the coordinates of a
+// geometry field, in the units of its spatial reference.  A zero
+// resolution is unknown.
+type CoordinatePrecision struct {
+	XYResolution float64
+	ZResolution  float64
+	MResolution  float64
+}
+
+// Fetch the coordinate precision of the geometry field.  It is unknown
+// before GDAL 3.9.
+func (gfd GeometryFieldDefinition) CoordinatePrecision() CoordinatePrecision {
+	var xy, z, m C.double
+	C.go_GFld_GetCoordinatePrecision(gfd.cval, &xy, &z, &m)
+	return CoordinatePrecision{float64(xy), float64(z), float64(m)}
+}
+
+// Set the coordinate precision of the geometry field.  Requires GDAL 3.9
+// or later.
+func (gfd GeometryFieldDefinition) SetCoordinatePrecision(prec CoordinatePrecision) error {
+	ok := C.go_GFld_SetCoordinatePrecision(
+		gfd.cval,
+		C.double(prec.XYResolution),
+		C.double(prec.ZResolution),
+		C.double(prec.MResolution),
+	)
+	if ok == 0 {
+		return fmt.Errorf("%s: coordinate precision requires GDAL 3.9 or later", ErrUnsupportedOperation)
+	}
+	return nil
+}
+
 /* -------------------------------------------------------------------- */
 /*      Feature definition functions                                    */
 /* -------------------------------------------------------------------- */
@@ -1122,6 +2331,37 @@ func (fd FeatureDefinition) DeleteFieldDefinition(index int) error {
 	return OGRErrContainer{ErrVal: cErr}.Err()
 }
 
+// Fetch the number of geometry fields in the feature definition
+func (fd FeatureDefinition) GeomFieldCount() int {
+	count := C.OGR_FD_GetGeomFieldCount(fd.cval)
+	return int(count)
+}
+
+// Fetch the definition of the indicated geometry field
+func (fd FeatureDefinition) GeomFieldDefinition(index int) GeometryFieldDefinition {
+	geomFieldDefn := C.OGR_FD_GetGeomFieldDefn(fd.cval, C.int(index))
+	return GeometryFieldDefinition{geomFieldDefn}
+}
+
+// Fetch the index of the named geometry field
+func (fd FeatureDefinition) GeomFieldIndex(name string) int {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	index := C.OGR_FD_GetGeomFieldIndex(fd.cval, cName)
+	return int(index)
+}
+
+// Add a new geometry field definition to this feature definition
+func (fd FeatureDefinition) AddGeomFieldDefinition(geomFieldDefn GeometryFieldDefinition) {
+	C.OGR_FD_AddGeomFieldDefn(fd.cval, geomFieldDefn.cval)
+}
+
+// Delete a geometry field definition from this feature definition
+func (fd FeatureDefinition) DeleteGeomFieldDefinition(index int) error {
+	cErr := C.OGR_FD_DeleteGeomFieldDefn(fd.cval, C.int(index))
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
+
 // Fetch the geometry base type of this feature definition
 func (fd FeatureDefinition) GeometryType() GeometryType {
 	gt := C.OGR_FD_GetGeomType(fd.cval)
@@ -1222,6 +2462,45 @@ func (feature Feature) StealGeometry() Geometry {
 	return Geometry{geom}
 }
 
+// Fetch the number of geometry fields of this feature
+func (feature Feature) GeomFieldCount() int {
+	count := C.OGR_F_GetGeomFieldCount(feature.cval)
+	return int(count)
+}
+
+// Fetch the definition of the indicated geometry field
+func (feature Feature) GeomFieldDefinition(index int) GeometryFieldDefinition {
+	geomFieldDefn := C.OGR_F_GetGeomFieldDefnRef(feature.cval, C.int(index))
+	return GeometryFieldDefinition{geomFieldDefn}
+}
+
+// Fetch the index of the named geometry field
+func (feature Feature) GeomFieldIndex(name string) int {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	index := C.OGR_F_GetGeomFieldIndex(feature.cval, cName)
+	return int(index)
+}
+
+// Fetch the geometry of the indicated geometry field
+func (feature Feature) GeometryField(index int) Geometry {
+	geom := C.OGR_F_GetGeomFieldRef(feature.cval, C.int(index))
+	return Geometry{geom}
+}
+
+// Set the geometry of the indicated geometry field to a copy of geom
+func (feature Feature) SetGeometryField(index int, geom Geometry) error {
+	cErr := C.OGR_F_SetGeomField(feature.cval, C.int(index), geom.cval)
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
+
+// Set the geometry of the indicated geometry field, passing ownership to
+// the feature
+func (feature Feature) SetGeometryFieldDirectly(index int, geom Geometry) error {
+	cErr := C.OGR_F_SetGeomFieldDirectly(feature.cval, C.int(index), geom.cval)
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
+
 // Duplicate feature
 func (feature Feature) Clone() Feature {
 	newFeature := C.OGR_F_Clone(feature.cval)
@@ -1271,6 +2550,17 @@ func (feature Feature) UnnsetField(index int) {
 	C.OGR_F_UnsetField(feature.cval, C.int(index))
 }
 
+// Test if a field is null
+func (feature Feature) IsFieldNull(index int) bool {
+	null := C.OGR_F_IsFieldNull(feature.cval, C.int(index))
+	return null != 0
+}
+
+// Clear a field and mark it as null
+func (feature Feature) SetFieldNull(index int) {
+	C.OGR_F_SetFieldNull(feature.cval, C.int(index))
+}
+
 // Fetch a reference to the internal field value
 func (feature Feature) RawField(index int) Field {
 	field := C.OGR_F_GetRawFieldRef(feature.cval, C.int(index))
@@ -1289,6 +2579,12 @@ func (feature Feature) FieldAsInteger64(index int) int64 {
 	return int64(val)
 }
 
+// Fetch field value as a boolean, true for any non-zero integer value
+func (feature Feature) FieldAsBool(index int) bool {
+	val := C.OGR_F_GetFieldAsInteger64(feature.cval, C.int(index))
+	return val != 0
+}
+
 // Fetch field value as float64
 func (feature Feature) FieldAsFloat64(index int) float64 {
 	val := C.OGR_F_GetFieldAsDouble(feature.cval, C.int(index))
@@ -1390,6 +2686,11 @@ func (feature Feature) SetFieldInteger(index, value int) {
 	C.OGR_F_SetFieldInteger(feature.cval, C.int(index), C.int(value))
 }
 
+// Set field to boolean value, stored as 1 or 0
+func (feature Feature) SetFieldBool(index int, value bool) {
+	C.OGR_F_SetFieldInteger(feature.cval, C.int(index), BoolToCInt(value))
+}
+
 // Set field to 64-bit integer value
 func (feature Feature) SetFieldInteger64(index int, value int64) {
 	C.OGR_F_SetFieldInteger64(feature.cval, C.int(index), C.GIntBig(value))
@@ -1516,11 +2817,16 @@ func (this Feature) SetFromWithMap(other Feature, forgiving int, fieldMap []int)
 }
 
 // Fetch style string for this feature
-func (feature Feature) StlyeString() string {
+func (feature Feature) StyleString() string {
 	style := C.OGR_F_GetStyleString(feature.cval)
 	return C.GoString(style)
 }
 
+// Deprecated: use StyleString
+func (feature Feature) StlyeString() string {
+	return feature.StyleString()
+}
+
 // Set style string for this feature
 func (feature Feature) SetStyleString(style string) {
 	cStyle := C.CString(style)
@@ -1588,6 +2894,89 @@ func (layer Layer) NextFeature() *Feature {
 	return &Feature{feature}
 }
 
+// FeatureIterator steps through the features of a layer.  Each feature is
+// destroyed when the iterator advances past it or is closed, so callers
+// that keep a feature beyond the current step must Clone it.
+//
+//	it := layer.Features()
+//	defer it.Close()
+//	for it.Next() {
+//		feature := it.Feature()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type FeatureIterator struct {
+	layer   Layer
+	feature Feature
+	err     error
+	done    bool
+}
+
+// Reset reading and return an iterator over the features of this layer
+func (layer Layer) Features() *FeatureIterator {
+	layer.ResetReading()
+	return &FeatureIterator{layer: layer}
+}
+
+// Advance to the next feature, destroying the current one.  Returns false
+// once the features are exhausted or a read error occurred.
+func (it *FeatureIterator) Next() bool {
+	it.release()
+	if it.done {
+		return false
+	}
+	C.CPLErrorReset()
+	feature := C.OGR_L_GetNextFeature(it.layer.cval)
+	if feature == nil {
+		it.err = CPLGetErr()
+		it.done = true
+		return false
+	}
+	it.feature = Feature{feature}
+	return true
+}
+
+// Fetch the current feature.  It remains valid until the next call to Next
+// or Close.
+func (it *FeatureIterator) Feature() Feature {
+	return it.feature
+}
+
+// Fetch the read error that stopped the iteration, if any
+func (it *FeatureIterator) Err() error {
+	return it.err
+}
+
+// Destroy the current feature and stop the iteration.  It is safe to call
+// Close more than once, and after the iteration has ended.
+func (it *FeatureIterator) Close() {
+	it.release()
+	it.done = true
+}
+
+func (it *FeatureIterator) release() {
+	if it.feature.cval != nil {
+		it.feature.Destroy()
+		it.feature = Feature{}
+	}
+}
+
+// Call fn for each feature of the layer, destroying each feature after fn
+// returns.  Iteration stops at the first error returned by fn or by the
+// layer.
+func (layer Layer) ForEachFeature(fn func(Feature) error) error {
+	it := layer.Features()
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Feature()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
 // Move read cursor to the provided index
 func (layer Layer) SetNextByIndex(index int64) error {
 	cErr := C.OGR_L_SetNextByIndex(layer.cval, C.GIntBig(index))
@@ -1737,33 +3126,192 @@ func (layer Layer) SetIgnoredFields(names []string) error {
 	return OGRErrContainer{ErrVal: cErr}.Err()
 }
 
-// Return the intersection of two layers
-// Unimplemented: Intersection
-// Will be new in 2.0
+// Options controlling the layer algebra methods.  The zero value uses the
+// GDAL defaults.
+type LayerAlgebraOptions struct {
+	// Skip features and geometries for which the operation fails, instead
+	// of returning an error
+	SkipFailures bool
+	// Promote polygons and line strings in the result to their multi types
+	PromoteToMulti bool
+	// Prefix for the names of the fields copied from the input layer
+	InputPrefix string
+	// Prefix for the names of the fields copied from the method layer
+	MethodPrefix string
+	// Drop result geometries of lower dimension than the input geometries
+	DropLowerDimensionGeometries bool
+	// Test for containment with prepared geometries before computing the
+	// intersection
+	PretestContainment bool
+	// Do not use prepared geometries to speed up the operation
+	DisablePreparedGeometries bool
+	// Additional NAME=VALUE options passed as is
+	Options []string
+}
+
+// Return the options as a string list
+func (opts *LayerAlgebraOptions) list() []string {
+	if opts == nil {
+		return nil
+	}
+	var list []string
+	if opts.SkipFailures {
+		list = append(list, "SKIP_FAILURES=YES")
+	}
+	if opts.PromoteToMulti {
+		list = append(list, "PROMOTE_TO_MULTI=YES")
+	}
+	if opts.InputPrefix != "" {
+		list = append(list, "INPUT_PREFIX="+opts.InputPrefix)
+	}
+	if opts.MethodPrefix != "" {
+		list = append(list, "METHOD_PREFIX="+opts.MethodPrefix)
+	}
+	if opts.DropLowerDimensionGeometries {
+		list = append(list, "KEEP_LOWER_DIMENSION_GEOMETRIES=NO")
+	}
+	if opts.PretestContainment {
+		list = append(list, "PRETEST_CONTAINMENT=YES")
+	}
+	if opts.DisablePreparedGeometries {
+		list = append(list, "USE_PREPARED_GEOMETRIES=NO")
+	}
+	return append(list, opts.Options...)
+}
 
-// Return the union of two layers
-// Unimplemented: Union
-// Will be new in 2.0
+type layerAlgebraOp int
 
-// Return the symmetric difference of two layers
-// Unimplemented: SymDifference
-// Will be new in 2.0
+const (
+	layerIntersection layerAlgebraOp = iota
+	layerUnion
+	layerSymDifference
+	layerIdentity
+	layerUpdate
+	layerClip
+	layerErase
+)
 
-// Identify features in this layer with ones from the provided layer
-// Unimplemented: Identity
-// Will be new in 2.0
+// Run a layer algebra operation, writing its output to the result layer
+func (layer Layer) algebra(
+	op layerAlgebraOp,
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	list := options.list()
+	length := len(list)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(list[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+	cOpts := (**C.char)(unsafe.Pointer(&opts[0]))
 
-// Update this layer with features from the provided layer
-// Unimplemented: Update
-// Will be new in 2.0
+	var pfn C.GDALProgressFunc
+	var arg *goGDALProgressFuncProxyArgs
+	if progress != nil {
+		pfn = C.goGDALProgressFuncProxyB()
+		arg = &goGDALProgressFuncProxyArgs{progress, data}
+	}
 
-// Clip off areas that are not covered by the provided layer
-// Unimplemented: Clip
-// Will be new in 2.0
+	var cErr C.OGRErr
+	switch op {
+	case layerIntersection:
+		cErr = C.OGR_L_Intersection(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	case layerUnion:
+		cErr = C.OGR_L_Union(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	case layerSymDifference:
+		cErr = C.OGR_L_SymDifference(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	case layerIdentity:
+		cErr = C.OGR_L_Identity(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	case layerUpdate:
+		cErr = C.OGR_L_Update(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	case layerClip:
+		cErr = C.OGR_L_Clip(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	case layerErase:
+		cErr = C.OGR_L_Erase(layer.cval, method.cval, result.cval, cOpts, pfn, unsafe.Pointer(arg))
+	}
+	return OGRErrContainer{ErrVal: cErr}.Err()
+}
 
-// Remove areas that are covered by the provided layer
-// Unimplemented: Erase
-// Will be new in 2.0
+// Write the intersection of this layer and the method layer to the result
+// layer.  The progress function may be nil.
+func (layer Layer) Intersection(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerIntersection, method, result, options, progress, data)
+}
+
+// Write the union of this layer and the method layer to the result layer.
+// The progress function may be nil.
+func (layer Layer) Union(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerUnion, method, result, options, progress, data)
+}
+
+// Write the symmetric difference of this layer and the method layer to the
+// result layer.  The progress function may be nil.
+func (layer Layer) SymDifference(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerSymDifference, method, result, options, progress, data)
+}
+
+// Identify features in this layer with ones from the method layer, writing
+// the result to the result layer.  The progress function may be nil.
+func (layer Layer) Identity(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerIdentity, method, result, options, progress, data)
+}
+
+// Update this layer with features from the method layer, writing the result
+// to the result layer.  The progress function may be nil.
+func (layer Layer) Update(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerUpdate, method, result, options, progress, data)
+}
+
+// Clip off areas of this layer that are not covered by the method layer,
+// writing the result to the result layer.  The progress function may be nil.
+func (layer Layer) Clip(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerClip, method, result, options, progress, data)
+}
+
+// Remove areas of this layer that are covered by the method layer, writing
+// the result to the result layer.  The progress function may be nil.
+func (layer Layer) Erase(
+	method, result Layer,
+	options *LayerAlgebraOptions,
+	progress ProgressFunc,
+	data interface{},
+) error {
+	return layer.algebra(layerErase, method, result, options, progress, data)
+}
 
 /* -------------------------------------------------------------------- */
 /*      Data source functions                                           */
@@ -2040,10 +3588,13 @@ func OGRDriverByName(name string) OGRDriver {
 /*      Style manager functions                                         */
 /* -------------------------------------------------------------------- */
 
-type StyleMgr struct {
+type StyleManager struct {
 	cval C.OGRStyleMgrH
 }
 
+// Deprecated: use StyleManager
+type StyleMgr = StyleManager
+
 type StyleTool struct {
 	cval C.OGRStyleToolH
 }
@@ -2052,60 +3603,428 @@ type StyleTable struct {
 	cval C.OGRStyleTableH
 }
 
-// Unimplemented: CreateStyleManager
+// StyleToolType identifies the kind of a style tool
+type StyleToolType int
+
+const (
+	STC_None   = StyleToolType(C.OGRSTCNone)
+	STC_Pen    = StyleToolType(C.OGRSTCPen)
+	STC_Brush  = StyleToolType(C.OGRSTCBrush)
+	STC_Symbol = StyleToolType(C.OGRSTCSymbol)
+	STC_Label  = StyleToolType(C.OGRSTCLabel)
+	STC_Vector = StyleToolType(C.OGRSTCVector)
+)
+
+// StyleUnit is the unit of the sizes of a style tool
+type StyleUnit int
+
+const (
+	STU_Ground = StyleUnit(C.OGRSTUGround)
+	STU_Pixel  = StyleUnit(C.OGRSTUPixel)
+	STU_Points = StyleUnit(C.OGRSTUPoints)
+	STU_MM     = StyleUnit(C.OGRSTUMM)
+	STU_CM     = StyleUnit(C.OGRSTUCM)
+	STU_Inches = StyleUnit(C.OGRSTUInches)
+)
+
+// StyleParam identifies a parameter of a style tool: a PenParam,
+// BrushParam, SymbolParam or LabelParam.  Each kind of tool numbers its
+// parameters independently, so a parameter only applies to the tools of
+// its kind.
+type StyleParam interface {
+	// Return the kind of tool the parameter applies to, its index and the
+	// number of parameters of that kind
+	styleParam() (toolType StyleToolType, index, count int)
+}
+
+// PenParam identifies a parameter of a pen tool
+type PenParam int
+
+// BrushParam identifies a parameter of a brush tool
+type BrushParam int
+
+// SymbolParam identifies a parameter of a symbol tool
+type SymbolParam int
+
+// LabelParam identifies a parameter of a label tool
+type LabelParam int
+
+func (param PenParam) styleParam() (StyleToolType, int, int) {
+	return STC_Pen, int(param), int(C.OGRSTPenLast)
+}
+
+func (param BrushParam) styleParam() (StyleToolType, int, int) {
+	return STC_Brush, int(param), int(C.OGRSTBrushLast)
+}
+
+func (param SymbolParam) styleParam() (StyleToolType, int, int) {
+	return STC_Symbol, int(param), int(C.OGRSTSymbolLast)
+}
+
+func (param LabelParam) styleParam() (StyleToolType, int, int) {
+	return STC_Label, int(param), int(C.OGRSTLabelLast)
+}
+
+// Pen parameters
+const (
+	STP_PenColor     = PenParam(C.OGRSTPenColor)
+	STP_PenWidth     = PenParam(C.OGRSTPenWidth)
+	STP_PenPattern   = PenParam(C.OGRSTPenPattern)
+	STP_PenId        = PenParam(C.OGRSTPenId)
+	STP_PenPerOffset = PenParam(C.OGRSTPenPerOffset)
+	STP_PenCap       = PenParam(C.OGRSTPenCap)
+	STP_PenJoin      = PenParam(C.OGRSTPenJoin)
+	STP_PenPriority  = PenParam(C.OGRSTPenPriority)
+)
+
+// Brush parameters
+const (
+	STP_BrushFColor   = BrushParam(C.OGRSTBrushFColor)
+	STP_BrushBColor   = BrushParam(C.OGRSTBrushBColor)
+	STP_BrushId       = BrushParam(C.OGRSTBrushId)
+	STP_BrushAngle    = BrushParam(C.OGRSTBrushAngle)
+	STP_BrushSize     = BrushParam(C.OGRSTBrushSize)
+	STP_BrushDx       = BrushParam(C.OGRSTBrushDx)
+	STP_BrushDy       = BrushParam(C.OGRSTBrushDy)
+	STP_BrushPriority = BrushParam(C.OGRSTBrushPriority)
+)
+
+// Symbol parameters
+const (
+	STP_SymbolId       = SymbolParam(C.OGRSTSymbolId)
+	STP_SymbolAngle    = SymbolParam(C.OGRSTSymbolAngle)
+	STP_SymbolColor    = SymbolParam(C.OGRSTSymbolColor)
+	STP_SymbolSize     = SymbolParam(C.OGRSTSymbolSize)
+	STP_SymbolDx       = SymbolParam(C.OGRSTSymbolDx)
+	STP_SymbolDy       = SymbolParam(C.OGRSTSymbolDy)
+	STP_SymbolStep     = SymbolParam(C.OGRSTSymbolStep)
+	STP_SymbolPerp     = SymbolParam(C.OGRSTSymbolPerp)
+	STP_SymbolOffset   = SymbolParam(C.OGRSTSymbolOffset)
+	STP_SymbolPriority = SymbolParam(C.OGRSTSymbolPriority)
+	STP_SymbolFontName = SymbolParam(C.OGRSTSymbolFontName)
+	STP_SymbolOColor   = SymbolParam(C.OGRSTSymbolOColor)
+)
+
+// Label parameters
+const (
+	STP_LabelFontName   = LabelParam(C.OGRSTLabelFontName)
+	STP_LabelSize       = LabelParam(C.OGRSTLabelSize)
+	STP_LabelTextString = LabelParam(C.OGRSTLabelTextString)
+	STP_LabelAngle      = LabelParam(C.OGRSTLabelAngle)
+	STP_LabelFColor     = LabelParam(C.OGRSTLabelFColor)
+	STP_LabelBColor     = LabelParam(C.OGRSTLabelBColor)
+	STP_LabelPlacement  = LabelParam(C.OGRSTLabelPlacement)
+	STP_LabelAnchor     = LabelParam(C.OGRSTLabelAnchor)
+	STP_LabelDx         = LabelParam(C.OGRSTLabelDx)
+	STP_LabelDy         = LabelParam(C.OGRSTLabelDy)
+	STP_LabelPerp       = LabelParam(C.OGRSTLabelPerp)
+	STP_LabelBold       = LabelParam(C.OGRSTLabelBold)
+	STP_LabelItalic     = LabelParam(C.OGRSTLabelItalic)
+	STP_LabelUnderline  = LabelParam(C.OGRSTLabelUnderline)
+	STP_LabelPriority   = LabelParam(C.OGRSTLabelPriority)
+	STP_LabelStrikeout  = LabelParam(C.OGRSTLabelStrikeout)
+	STP_LabelStretch    = LabelParam(C.OGRSTLabelStretch)
+	STP_LabelHColor     = LabelParam(C.OGRSTLabelHColor)
+	STP_LabelOColor     = LabelParam(C.OGRSTLabelOColor)
+)
+
+// Create a style manager, resolving named styles such as "@name" in the
+// given style table, which may be null
+func CreateStyleManager(table StyleTable) StyleManager {
+	sm := C.OGR_SM_Create(table.cval)
+	return StyleManager{sm}
+}
+
+// Destroy the style manager
+func (sm StyleManager) Destroy() {
+	C.OGR_SM_Destroy(sm.cval)
+}
+
+// Initialize the style manager from the style string of a feature, and
+// return that style string
+func (sm StyleManager) InitFromFeature(feature Feature) string {
+	style := C.OGR_SM_InitFromFeature(sm.cval, feature.cval)
+	return C.GoString(style)
+}
+
+// Initialize the style manager from a style string
+func (sm StyleManager) InitStyleString(style string) error {
+	cStyle := C.CString(style)
+	defer C.free(unsafe.Pointer(cStyle))
+	if C.OGR_SM_InitStyleString(sm.cval, cStyle) == 0 {
+		return fmt.Errorf("%s: invalid style string %q", ErrIllegal, style)
+	}
+	return nil
+}
+
+// Fetch the number of parts in the style
+func (sm StyleManager) PartCount() int {
+	count := C.OGR_SM_GetPartCount(sm.cval, nil)
+	return int(count)
+}
+
+// Fetch a part of the style as a style tool, which the caller must
+// destroy.  Returns a null tool if index is out of range.
+func (sm StyleManager) Part(index int) StyleTool {
+	st := C.OGR_SM_GetPart(sm.cval, C.int(index), nil)
+	return StyleTool{st}
+}
+
+// Add a part to the style
+func (sm StyleManager) AddPart(tool StyleTool) error {
+	if C.OGR_SM_AddPart(sm.cval, tool.cval) == 0 {
+		return fmt.Errorf("%s: cannot add style part", ErrFailure)
+	}
+	return nil
+}
+
+// Add a named style to the style table of the style manager, or add the
+// current style string under that name if style is empty
+func (sm StyleManager) AddStyle(name, style string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cStyle *C.char
+	if style != "" {
+		cStyle = C.CString(style)
+		defer C.free(unsafe.Pointer(cStyle))
+	}
+	if C.OGR_SM_AddStyle(sm.cval, cName, cStyle) == 0 {
+		return fmt.Errorf("%s: cannot add style %q", ErrFailure, name)
+	}
+	return nil
+}
 
-// Unimplemented: Destroy
+// Create a style tool of the given kind
+func CreateStyleTool(toolType StyleToolType) StyleTool {
+	st := C.OGR_ST_Create(C.OGRSTClassId(toolType))
+	return StyleTool{st}
+}
 
-// Unimplemented: InitFromFeature
+// Destroy the style tool
+func (st StyleTool) Destroy() {
+	C.OGR_ST_Destroy(st.cval)
+}
 
-// Unimplemented: InitStyleString
+// Fetch the kind of the style tool
+func (st StyleTool) Type() StyleToolType {
+	toolType := C.OGR_ST_GetType(st.cval)
+	return StyleToolType(toolType)
+}
 
-// Unimplemented: PartCount
+// Fetch the unit of the sizes of the style tool
+func (st StyleTool) Unit() StyleUnit {
+	unit := C.OGR_ST_GetUnit(st.cval)
+	return StyleUnit(unit)
+}
 
-// Unimplemented: PartCount
+// Set the unit in which sizes are returned.  groundPaperScale is the
+// number of ground units per paper unit, used to convert ground sizes.
+func (st StyleTool) SetUnit(unit StyleUnit, groundPaperScale float64) {
+	C.OGR_ST_SetUnit(st.cval, C.OGRSTUnitId(unit), C.double(groundPaperScale))
+}
 
-// Unimplemented: AddPart
+// Return the index of a parameter in the parameters of the style tool, and
+// false if the parameter does not apply to the tool.  GDAL does not check
+// the index against the kind of the tool, so this must be done before
+// passing it on.
+func (st StyleTool) paramIndex(param StyleParam) (C.int, bool) {
+	if param == nil {
+		return 0, false
+	}
+	toolType, index, count := param.styleParam()
+	if toolType != st.Type() || index < 0 || index >= count {
+		return 0, false
+	}
+	return C.int(index), true
+}
 
-// Unimplemented: AddStyle
+func (st StyleTool) paramError(param StyleParam) error {
+	return fmt.Errorf("%s: style parameter %v does not apply to the tool", ErrIllegal, param)
+}
 
-// Unimplemented: CreateStyleTool
+// Fetch a parameter as a string, and false if it is not set or does not
+// apply to the style tool
+func (st StyleTool) ParamString(param StyleParam) (string, bool) {
+	index, ok := st.paramIndex(param)
+	if !ok {
+		return "", false
+	}
+	var isNull C.int
+	val := C.OGR_ST_GetParamStr(st.cval, index, &isNull)
+	return C.GoString(val), isNull == 0
+}
 
-// Unimplemented: Destroy
+// Fetch a parameter as an integer, and false if it is not set or does not
+// apply to the style tool
+func (st StyleTool) ParamInt(param StyleParam) (int, bool) {
+	index, ok := st.paramIndex(param)
+	if !ok {
+		return 0, false
+	}
+	var isNull C.int
+	val := C.OGR_ST_GetParamNum(st.cval, index, &isNull)
+	return int(val), isNull == 0
+}
+
+// Fetch a parameter as a float64, and false if it is not set or does not
+// apply to the style tool.  Sizes are converted to the unit of the style
+// tool.
+func (st StyleTool) ParamFloat64(param StyleParam) (float64, bool) {
+	index, ok := st.paramIndex(param)
+	if !ok {
+		return 0, false
+	}
+	var isNull C.int
+	val := C.OGR_ST_GetParamDbl(st.cval, index, &isNull)
+	return float64(val), isNull == 0
+}
 
-// Unimplemented: Type
+// Fetch a color parameter, such as STP_PenColor, as red, green, blue and
+// alpha components, and false if it is not set or not a valid color
+func (st StyleTool) ParamColor(param StyleParam) (r, g, b, a uint8, ok bool) {
+	color, ok := st.ParamString(param)
+	if !ok {
+		return 0, 0, 0, 0, false
+	}
+	return st.RGBFromString(color)
+}
 
-// Unimplemented: Unit
+// Set a parameter to a string
+func (st StyleTool) SetParamString(param StyleParam, value string) error {
+	index, ok := st.paramIndex(param)
+	if !ok {
+		return st.paramError(param)
+	}
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+	C.OGR_ST_SetParamStr(st.cval, index, cValue)
+	return nil
+}
 
-// Unimplemented: SetUnit
+// Set a parameter to an integer
+func (st StyleTool) SetParamInt(param StyleParam, value int) error {
+	index, ok := st.paramIndex(param)
+	if !ok {
+		return st.paramError(param)
+	}
+	C.OGR_ST_SetParamNum(st.cval, index, C.int(value))
+	return nil
+}
 
-// Unimplemented: ParamStr
+// Set a parameter to a float64
+func (st StyleTool) SetParamFloat64(param StyleParam, value float64) error {
+	index, ok := st.paramIndex(param)
+	if !ok {
+		return st.paramError(param)
+	}
+	C.OGR_ST_SetParamDbl(st.cval, index, C.double(value))
+	return nil
+}
 
-// Unimplemented: ParamNum
+// Fetch the style string of the style tool
+func (st StyleTool) StyleString() string {
+	style := C.OGR_ST_GetStyleString(st.cval)
+	return C.GoString(style)
+}
 
-// Unimplemented: ParamDbl
+// Parse a color in #RRGGBB[AA] form into its components, and false if it
+// is not a valid color.  Alpha is 255 when not given.
+func (st StyleTool) RGBFromString(color string) (r, g, b, a uint8, ok bool) {
+	cColor := C.CString(color)
+	defer C.free(unsafe.Pointer(cColor))
+	var red, green, blue, alpha C.int
+	val := C.OGR_ST_GetRGBFromString(st.cval, cColor, &red, &green, &blue, &alpha)
+	return uint8(red), uint8(green), uint8(blue), uint8(alpha), val != 0
+}
 
-// Unimplemented: SetParamStr
+// Create an empty style table
+func CreateStyleTable() StyleTable {
+	table := C.OGR_STBL_Create()
+	return StyleTable{table}
+}
 
-// Unimplemented: SetParamNum
+// Destroy the style table
+func (table StyleTable) Destroy() {
+	C.OGR_STBL_Destroy(table.cval)
+}
 
-// Unimplemented: SetParamDbl
+// Add a named style to the table
+func (table StyleTable) AddStyle(name, style string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cStyle := C.CString(style)
+	defer C.free(unsafe.Pointer(cStyle))
+	if C.OGR_STBL_AddStyle(table.cval, cName, cStyle) == 0 {
+		return fmt.Errorf("%s: cannot add style %q", ErrFailure, name)
+	}
+	return nil
+}
 
-// Unimplemented: StyleString
+// Save the style table to a file
+func (table StyleTable) Save(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+	if C.OGR_STBL_SaveStyleTable(table.cval, cFilename) == 0 {
+		return fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return nil
+}
 
-// Unimplemented: RGBFromString
+// Load the style table from a file
+func (table StyleTable) Load(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+	if C.OGR_STBL_LoadStyleTable(table.cval, cFilename) == 0 {
+		return fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return nil
+}
 
-// Unimplemented: CreateStyleTable
+// Fetch the style string of a named style, and false if there is none
+func (table StyleTable) Find(name string) (string, bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	style := C.OGR_STBL_Find(table.cval, cName)
+	return C.GoString(style), style != nil
+}
 
-// Unimplemented: Destroy
+// Reset the reading of styles to the first style
+func (table StyleTable) ResetStyleStringReading() {
+	C.OGR_STBL_ResetStyleStringReading(table.cval)
+}
 
-// Unimplemented: Save
+// Fetch the next style string, and false once all have been read
+func (table StyleTable) NextStyle() (string, bool) {
+	style := C.OGR_STBL_GetNextStyle(table.cval)
+	return C.GoString(style), style != nil
+}
 
-// Unimplemented: Load
+// Fetch the name of the style last returned by NextStyle
+func (table StyleTable) LastStyleName() string {
+	name := C.OGR_STBL_GetLastStyleName(table.cval)
+	return C.GoString(name)
+}
 
-// Unimplemented: Find
+// Fetch the style table of the dataset, which remains owned by the
+// dataset.  Returns a null table if there is none.
+func (dataset Dataset) StyleTable() StyleTable {
+	table := C.GDALDatasetGetStyleTable(dataset.cval)
+	return StyleTable{table}
+}
 
-// Unimplemented: ResetStyleStringReading
+// Set the style table of the dataset to a copy of table
+func (dataset Dataset) SetStyleTable(table StyleTable) {
+	C.GDALDatasetSetStyleTable(dataset.cval, table.cval)
+}
 
-// Unimplemented: NextStyle
+// Fetch the style table of the layer, which remains owned by the layer.
+// Returns a null table if there is none.
+func (layer Layer) StyleTable() StyleTable {
+	table := C.OGR_L_GetStyleTable(layer.cval)
+	return StyleTable{table}
+}
 
-// Unimplemented: LastStyleName
+// Set the style table of the layer to a copy of table
+func (layer Layer) SetStyleTable(table StyleTable) {
+	C.OGR_L_SetStyleTable(layer.cval, table.cval)
+}
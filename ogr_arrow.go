@@ -0,0 +1,175 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_ogr_arrow.h"
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Arrow C data interface                                          */
+/* -------------------------------------------------------------------- */
+
+// ArrowArrayStream is a C-allocated struct ArrowArrayStream, as defined by
+// the Arrow C stream interface.  It can be handed to the Apache Arrow Go
+// library with
+//
+//	reader, err := cdata.ImportCArrowStream(
+//		(*cdata.CArrowArrayStream)(stream.Pointer()), nil)
+//
+// which takes ownership of the stream contents.  Release must still be
+// called to free the struct itself.
+type ArrowArrayStream struct {
+	cval unsafe.Pointer
+}
+
+// ArrowSchema is a C-allocated struct ArrowSchema
+type ArrowSchema struct {
+	cval unsafe.Pointer
+}
+
+// ArrowArray is a C-allocated struct ArrowArray
+type ArrowArray struct {
+	cval unsafe.Pointer
+}
+
+// Return a stream of Arrow record batches reading the features of the
+// layer.  Options are the layer's Arrow stream options, such as
+// "MAX_FEATURES_IN_BATCH=65536" or "INCLUDE_FID=NO".  Requires GDAL 3.6
+// or later.
+func (layer Layer) ArrowStream(options []string) (ArrowArrayStream, error) {
+	if VERSION_NUM < 3060000 {
+		return ArrowArrayStream{}, fmt.Errorf("%s: Arrow streams require GDAL 3.6 or later", ErrUnsupportedOperation)
+	}
+
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	stream := C.go_ArrowArrayStreamNew()
+	if C.go_OGR_L_GetArrowStream(layer.cval, stream, (**C.char)(unsafe.Pointer(&opts[0]))) != 1 {
+		C.go_ArrowArrayStreamFree(stream)
+		return ArrowArrayStream{}, fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return ArrowArrayStream{stream}, nil
+}
+
+// Return a pointer to the underlying struct ArrowArrayStream
+func (stream ArrowArrayStream) Pointer() unsafe.Pointer {
+	return stream.cval
+}
+
+// Release the stream, if it has not been moved out, and free it
+func (stream ArrowArrayStream) Release() {
+	C.go_ArrowArrayStreamFree(stream.cval)
+}
+
+// Fetch the schema of the batches returned by the stream
+func (stream ArrowArrayStream) Schema() (ArrowSchema, error) {
+	schema := C.go_ArrowSchemaNew()
+	if errno := C.go_ArrowArrayStreamGetSchema(stream.cval, schema); errno != 0 {
+		C.go_ArrowSchemaFree(schema)
+		return ArrowSchema{}, stream.lastError(errno)
+	}
+	return ArrowSchema{schema}, nil
+}
+
+// Fetch the next record batch of the stream, or io.EOF once the stream is
+// exhausted
+func (stream ArrowArrayStream) Next() (ArrowArray, error) {
+	array := C.go_ArrowArrayNew()
+	if errno := C.go_ArrowArrayStreamGetNext(stream.cval, array); errno != 0 {
+		C.go_ArrowArrayFree(array)
+		return ArrowArray{}, stream.lastError(errno)
+	}
+	if C.go_ArrowArrayIsReleased(array) != 0 {
+		C.go_ArrowArrayFree(array)
+		return ArrowArray{}, io.EOF
+	}
+	return ArrowArray{array}, nil
+}
+
+func (stream ArrowArrayStream) lastError(errno C.int) error {
+	if msg := C.go_ArrowArrayStreamGetLastError(stream.cval); msg != nil {
+		return fmt.Errorf("Error: %s", C.GoString(msg))
+	}
+	return fmt.Errorf("Error: Arrow stream error %d", int(errno))
+}
+
+// Return a pointer to the underlying struct ArrowSchema
+func (schema ArrowSchema) Pointer() unsafe.Pointer {
+	return schema.cval
+}
+
+// Release the schema, if it has not been moved out, and free it
+func (schema ArrowSchema) Release() {
+	C.go_ArrowSchemaFree(schema.cval)
+}
+
+// Return a pointer to the underlying struct ArrowArray
+func (array ArrowArray) Pointer() unsafe.Pointer {
+	return array.cval
+}
+
+// Fetch the number of rows in the batch
+func (array ArrowArray) Length() int64 {
+	return int64(C.go_ArrowArrayLength(array.cval))
+}
+
+// Release the array, if it has not been moved out, and free it
+func (array ArrowArray) Release() {
+	C.go_ArrowArrayFree(array.cval)
+}
+
+// Write a record batch to the layer.  schema and array point to a struct
+// ArrowSchema and struct ArrowArray, such as those filled in by
+// cdata.ExportArrowRecordBatch or returned by ArrowArrayStream.  The array
+// is released by the call.  Requires GDAL 3.8 or later.
+func (layer Layer) WriteArrowBatch(schema, array unsafe.Pointer, options []string) error {
+	if VERSION_NUM < 3080000 {
+		return fmt.Errorf("%s: writing Arrow batches requires GDAL 3.8 or later", ErrUnsupportedOperation)
+	}
+
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	if C.go_OGR_L_WriteArrowBatch(layer.cval, schema, array, (**C.char)(unsafe.Pointer(&opts[0]))) != 1 {
+		return fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return nil
+}
+
+// Create a field on the layer from a child of an Arrow schema.  Requires
+// GDAL 3.8 or later.
+func (layer Layer) CreateFieldFromArrowSchema(schema unsafe.Pointer, options []string) error {
+	if VERSION_NUM < 3080000 {
+		return fmt.Errorf("%s: Arrow schemas require GDAL 3.8 or later", ErrUnsupportedOperation)
+	}
+
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	if C.go_OGR_L_CreateFieldFromArrowSchema(layer.cval, schema, (**C.char)(unsafe.Pointer(&opts[0]))) != 1 {
+		return fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return nil
+}
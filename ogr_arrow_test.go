@@ -0,0 +1,62 @@
+package gdal
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerArrowStream(t *testing.T) {
+	if VERSION_NUM < 3060000 {
+		t.Skip("Arrow streams require GDAL 3.6")
+	}
+	ds, err := OpenEx("testdata/test.shp", OFReadOnly|OFVector, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer ds.Close()
+	layer := ds.LayerByIndex(0)
+	expected, _ := layer.FeatureCount(true)
+
+	stream, err := layer.ArrowStream([]string{"MAX_FEATURES_IN_BATCH=1", "INCLUDE_FID=NO"})
+	if err != nil {
+		t.Fatalf("ArrowStream: %v", err)
+	}
+	defer stream.Release()
+
+	schema, err := stream.Schema()
+	if err != nil {
+		t.Fatalf("Schema: %v", err)
+	}
+	defer schema.Release()
+
+	out := createMemoryDataset(t)
+	defer out.Close()
+	copied := out.CreateLayer("copy", SpatialReference{}, GT_Unknown, nil)
+	for i := 0; i < layer.Definition().FieldCount(); i++ {
+		assert.NoError(t, copied.CreateField(layer.Definition().FieldDefinition(i), true))
+	}
+
+	var rows int64
+	for {
+		batch, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		rows += batch.Length()
+		if VERSION_NUM >= 3080000 {
+			assert.NoError(t, copied.WriteArrowBatch(schema.Pointer(), batch.Pointer(), nil))
+		}
+		batch.Release()
+	}
+	assert.Equal(t, int64(expected), rows)
+
+	if VERSION_NUM >= 3080000 {
+		count, _ := copied.FeatureCount(true)
+		assert.Equal(t, expected, count)
+	}
+}
@@ -0,0 +1,409 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_ogr_domain.h"
+*/
+import "C"
+import (
+	"fmt"
+	"math"
+	"time"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Field domains                                                   */
+/* -------------------------------------------------------------------- */
+
+// Field domains restrict the values of the fields that reference them by
+// name.  They require GDAL 3.3 or later; listing and deleting the domains
+// of a dataset requires GDAL 3.5 or later.
+
+type FieldDomain struct {
+	cval unsafe.Pointer
+}
+
+// List of field domain types
+type FieldDomainType int
+
+const (
+	FDT_Coded = FieldDomainType(0)
+	FDT_Range = FieldDomainType(1)
+	FDT_Glob  = FieldDomainType(2)
+)
+
+// Policy applied to a field when the feature holding it is split
+type FieldDomainSplitPolicy int
+
+const (
+	FDSP_DefaultValue  = FieldDomainSplitPolicy(0)
+	FDSP_Duplicate     = FieldDomainSplitPolicy(1)
+	FDSP_GeometryRatio = FieldDomainSplitPolicy(2)
+)
+
+// Policy applied to a field when the features holding it are merged
+type FieldDomainMergePolicy int
+
+const (
+	FDMP_DefaultValue     = FieldDomainMergePolicy(0)
+	FDMP_Sum              = FieldDomainMergePolicy(1)
+	FDMP_GeometryWeighted = FieldDomainMergePolicy(2)
+)
+
+// CodedValue is one entry of a coded value field domain
+type CodedValue struct {
+	Code  string
+	Value string
+}
+
+// RangeBound is one end of a range field domain.  Value holds an int64
+// for Integer and Integer64 domains, a float64 for Real domains and a
+// time.Time for DateTime domains.
+type RangeBound struct {
+	Value     interface{}
+	Inclusive bool
+}
+
+func errFieldDomainsUnsupported() error {
+	return fmt.Errorf("%s: field domains require GDAL 3.3 or later", ErrUnsupportedOperation)
+}
+
+// Create a field domain whose values are restricted to a list of codes
+func CreateCodedFieldDomain(
+	name, description string,
+	fieldType FieldType,
+	subType FieldSubType,
+	values []CodedValue,
+) (FieldDomain, error) {
+	if VERSION_NUM < 3030000 {
+		return FieldDomain{}, errFieldDomainsUnsupported()
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cDescription := C.CString(description)
+	defer C.free(unsafe.Pointer(cDescription))
+
+	length := len(values)
+	codes := make([]*C.char, length+1)
+	vals := make([]*C.char, length+1)
+	for i, value := range values {
+		codes[i] = C.CString(value.Code)
+		defer C.free(unsafe.Pointer(codes[i]))
+		vals[i] = C.CString(value.Value)
+		defer C.free(unsafe.Pointer(vals[i]))
+	}
+
+	domain := C.go_CodedFldDomain_Create(
+		cName, cDescription, C.int(fieldType), C.int(subType),
+		(**C.char)(unsafe.Pointer(&codes[0])),
+		(**C.char)(unsafe.Pointer(&vals[0])),
+		C.int(length),
+	)
+	if domain == nil {
+		return FieldDomain{}, fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return FieldDomain{domain}, nil
+}
+
+// Create a field domain whose values are restricted to a range.  A nil
+// bound leaves that end of the range open.
+func CreateRangeFieldDomain(
+	name, description string,
+	fieldType FieldType,
+	subType FieldSubType,
+	min, max *RangeBound,
+) (FieldDomain, error) {
+	if VERSION_NUM < 3030000 {
+		return FieldDomain{}, errFieldDomainsUnsupported()
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cDescription := C.CString(description)
+	defer C.free(unsafe.Pointer(cDescription))
+
+	var cMin, cMax C.goFieldDomainBound
+	if err := min.toC(&cMin); err != nil {
+		return FieldDomain{}, err
+	}
+	if err := max.toC(&cMax); err != nil {
+		return FieldDomain{}, err
+	}
+
+	domain := C.go_RangeFldDomain_Create(
+		cName, cDescription, C.int(fieldType), C.int(subType), &cMin, &cMax,
+	)
+	if domain == nil {
+		return FieldDomain{}, fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return FieldDomain{domain}, nil
+}
+
+// Create a field domain whose values must match a glob pattern
+func CreateGlobFieldDomain(
+	name, description string,
+	fieldType FieldType,
+	subType FieldSubType,
+	glob string,
+) (FieldDomain, error) {
+	if VERSION_NUM < 3030000 {
+		return FieldDomain{}, errFieldDomainsUnsupported()
+	}
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	cDescription := C.CString(description)
+	defer C.free(unsafe.Pointer(cDescription))
+	cGlob := C.CString(glob)
+	defer C.free(unsafe.Pointer(cGlob))
+
+	domain := C.go_GlobFldDomain_Create(
+		cName, cDescription, C.int(fieldType), C.int(subType), cGlob,
+	)
+	if domain == nil {
+		return FieldDomain{}, fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return FieldDomain{domain}, nil
+}
+
+// Destroy a field domain created with one of the Create*FieldDomain
+// functions.  Domains fetched from a dataset are owned by it and must not
+// be destroyed.
+func (domain FieldDomain) Destroy() {
+	C.go_FldDomain_Destroy(domain.cval)
+}
+
+// Fetch the name of the domain
+func (domain FieldDomain) Name() string {
+	return C.GoString(C.go_FldDomain_GetName(domain.cval))
+}
+
+// Fetch the description of the domain
+func (domain FieldDomain) Description() string {
+	return C.GoString(C.go_FldDomain_GetDescription(domain.cval))
+}
+
+// Fetch the type of the domain
+func (domain FieldDomain) Type() FieldDomainType {
+	return FieldDomainType(C.go_FldDomain_GetDomainType(domain.cval))
+}
+
+// Fetch the type of the fields the domain applies to
+func (domain FieldDomain) FieldType() FieldType {
+	return FieldType(C.go_FldDomain_GetFieldType(domain.cval))
+}
+
+// Fetch the subtype of the fields the domain applies to
+func (domain FieldDomain) FieldSubType() FieldSubType {
+	return FieldSubType(C.go_FldDomain_GetFieldSubType(domain.cval))
+}
+
+// Fetch the split policy of the domain
+func (domain FieldDomain) SplitPolicy() FieldDomainSplitPolicy {
+	return FieldDomainSplitPolicy(C.go_FldDomain_GetSplitPolicy(domain.cval))
+}
+
+// Set the split policy of the domain
+func (domain FieldDomain) SetSplitPolicy(policy FieldDomainSplitPolicy) {
+	C.go_FldDomain_SetSplitPolicy(domain.cval, C.int(policy))
+}
+
+// Fetch the merge policy of the domain
+func (domain FieldDomain) MergePolicy() FieldDomainMergePolicy {
+	return FieldDomainMergePolicy(C.go_FldDomain_GetMergePolicy(domain.cval))
+}
+
+// Set the merge policy of the domain
+func (domain FieldDomain) SetMergePolicy(policy FieldDomainMergePolicy) {
+	C.go_FldDomain_SetMergePolicy(domain.cval, C.int(policy))
+}
+
+// Fetch the codes of a coded value domain
+func (domain FieldDomain) CodedValues() []CodedValue {
+	if domain.Type() != FDT_Coded {
+		return nil
+	}
+	count := int(C.go_CodedFldDomain_GetCount(domain.cval))
+	values := make([]CodedValue, count)
+	for i := range values {
+		values[i].Code = C.GoString(C.go_CodedFldDomain_GetCode(domain.cval, C.int(i)))
+		values[i].Value = C.GoString(C.go_CodedFldDomain_GetValue(domain.cval, C.int(i)))
+	}
+	return values
+}
+
+// Fetch the lower bound of a range domain, or nil if it is open
+func (domain FieldDomain) RangeMin() *RangeBound {
+	if domain.Type() != FDT_Range {
+		return nil
+	}
+	var bound C.goFieldDomainBound
+	C.go_RangeFldDomain_GetMin(domain.cval, &bound)
+	return rangeBoundFromC(domain.FieldType(), &bound)
+}
+
+// Fetch the upper bound of a range domain, or nil if it is open
+func (domain FieldDomain) RangeMax() *RangeBound {
+	if domain.Type() != FDT_Range {
+		return nil
+	}
+	var bound C.goFieldDomainBound
+	C.go_RangeFldDomain_GetMax(domain.cval, &bound)
+	return rangeBoundFromC(domain.FieldType(), &bound)
+}
+
+// Fetch the pattern of a glob domain
+func (domain FieldDomain) Glob() string {
+	if domain.Type() != FDT_Glob {
+		return ""
+	}
+	return C.GoString(C.go_GlobFldDomain_GetGlob(domain.cval))
+}
+
+// Fill in the C representation of a range bound
+func (bound *RangeBound) toC(cBound *C.goFieldDomainBound) error {
+	if bound == nil {
+		return nil
+	}
+	cBound.isSet = 1
+	if bound.Inclusive {
+		cBound.inclusive = 1
+	}
+	switch v := bound.Value.(type) {
+	case int:
+		cBound.integer = C.longlong(v)
+		cBound.real = C.double(v)
+	case int32:
+		cBound.integer = C.longlong(v)
+		cBound.real = C.double(v)
+	case int64:
+		cBound.integer = C.longlong(v)
+		cBound.real = C.double(v)
+	case float64:
+		cBound.integer = C.longlong(v)
+		cBound.real = C.double(v)
+	case time.Time:
+		_, offset := v.Zone()
+		cBound.year = C.int(v.Year())
+		cBound.month = C.int(v.Month())
+		cBound.day = C.int(v.Day())
+		cBound.hour = C.int(v.Hour())
+		cBound.minute = C.int(v.Minute())
+		cBound.second = C.float(float64(v.Second()) + float64(v.Nanosecond())/1e9)
+		cBound.tzFlag = C.int(100 + offset/(15*60))
+	default:
+		return fmt.Errorf("unsupported range bound type %T", bound.Value)
+	}
+	return nil
+}
+
+// Convert the C representation of a range bound
+func rangeBoundFromC(fieldType FieldType, cBound *C.goFieldDomainBound) *RangeBound {
+	if cBound.isSet == 0 {
+		return nil
+	}
+	bound := &RangeBound{Inclusive: cBound.inclusive != 0}
+	switch fieldType {
+	case FT_Integer, FT_Integer64:
+		bound.Value = int64(cBound.integer)
+	case FT_Real:
+		bound.Value = float64(cBound.real)
+	default:
+		loc := time.UTC
+		switch {
+		case cBound.tzFlag == 1:
+			loc = time.Local
+		case cBound.tzFlag > 1:
+			loc = time.FixedZone("", int(cBound.tzFlag-100)*15*60)
+		}
+		sec := float64(cBound.second)
+		whole := math.Floor(sec)
+		bound.Value = time.Date(
+			int(cBound.year), time.Month(cBound.month), int(cBound.day),
+			int(cBound.hour), int(cBound.minute), int(whole),
+			int(math.Round((sec-whole)*1e9)), loc,
+		)
+	}
+	return bound
+}
+
+// Fetch the names of the field domains of the dataset.  Requires GDAL 3.5
+// or later.
+func (dataset Dataset) FieldDomainNames(options []string) []string {
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	p := C.go_DatasetGetFieldDomainNames(dataset.cval, (**C.char)(unsafe.Pointer(&opts[0])))
+	defer C.CSLDestroy(p)
+	count := int(C.CSLCount(p))
+	names := make([]string, count)
+	for i := range names {
+		names[i] = C.GoString(C.CSLGetField(p, C.int(i)))
+	}
+	return names
+}
+
+// Fetch a field domain of the dataset by name.  The domain is owned by the
+// dataset; the returned domain is null if there is no such domain.
+func (dataset Dataset) FieldDomain(name string) FieldDomain {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return FieldDomain{C.go_DatasetGetFieldDomain(dataset.cval, cName)}
+}
+
+// Add a field domain to the dataset.  The domain is copied, so the caller
+// still has to destroy it.
+func (dataset Dataset) AddFieldDomain(domain FieldDomain) error {
+	var reason *C.char
+	switch C.go_DatasetAddFieldDomain(dataset.cval, domain.cval, &reason) {
+	case 1:
+		return nil
+	case -1:
+		return errFieldDomainsUnsupported()
+	}
+	return fieldDomainError(reason)
+}
+
+// Delete a field domain from the dataset.  Requires GDAL 3.5 or later.
+func (dataset Dataset) DeleteFieldDomain(name string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var reason *C.char
+	switch C.go_DatasetDeleteFieldDomain(dataset.cval, cName, &reason) {
+	case 1:
+		return nil
+	case -1:
+		return fmt.Errorf("%s: deleting field domains requires GDAL 3.5 or later", ErrUnsupportedOperation)
+	}
+	return fieldDomainError(reason)
+}
+
+// Return the error for a failed domain operation, freeing its reason
+func fieldDomainError(reason *C.char) error {
+	if reason == nil {
+		return fmt.Errorf("Error: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	defer C.CPLFree(unsafe.Pointer(reason))
+	return fmt.Errorf("Error: %s", C.GoString(reason))
+}
+
+// Fetch the name of the field domain of this field, if any
+func (fd FieldDefinition) DomainName() string {
+	return C.GoString(C.go_Fld_GetDomainName(fd.cval))
+}
+
+// Set the name of the field domain of this field.  An empty name removes
+// the domain.
+func (fd FieldDefinition) SetDomainName(name string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	if C.go_Fld_SetDomainName(fd.cval, cName) == 0 {
+		return errFieldDomainsUnsupported()
+	}
+	return nil
+}
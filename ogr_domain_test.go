@@ -0,0 +1,74 @@
+package gdal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldDomains(t *testing.T) {
+	if VERSION_NUM < 3050000 {
+		t.Skip("field domains require GDAL 3.5")
+	}
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+
+	coded, err := CreateCodedFieldDomain("status", "asset status", FT_String, FST_None, []CodedValue{
+		{"A", "Active"},
+		{"R", "Retired"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCodedFieldDomain: %v", err)
+	}
+	coded.SetSplitPolicy(FDSP_Duplicate)
+	assert.NoError(t, ds.AddFieldDomain(coded))
+	coded.Destroy()
+
+	ranged, err := CreateRangeFieldDomain("diameter", "", FT_Real, FST_None,
+		&RangeBound{Value: 0.0, Inclusive: true}, nil)
+	if err != nil {
+		t.Fatalf("CreateRangeFieldDomain: %v", err)
+	}
+	assert.NoError(t, ds.AddFieldDomain(ranged))
+	ranged.Destroy()
+
+	glob, err := CreateGlobFieldDomain("code", "", FT_String, FST_None, "[A-Z]*")
+	if err != nil {
+		t.Fatalf("CreateGlobFieldDomain: %v", err)
+	}
+	assert.NoError(t, ds.AddFieldDomain(glob))
+	assert.Error(t, ds.AddFieldDomain(glob))
+	glob.Destroy()
+
+	assert.ElementsMatch(t, []string{"status", "diameter", "code"}, ds.FieldDomainNames(nil))
+
+	domain := ds.FieldDomain("status")
+	if assert.False(t, domain.IsNull()) {
+		assert.Equal(t, FDT_Coded, domain.Type())
+		assert.Equal(t, "asset status", domain.Description())
+		assert.Equal(t, FT_String, domain.FieldType())
+		assert.Equal(t, FDSP_Duplicate, domain.SplitPolicy())
+		assert.Equal(t, FDMP_DefaultValue, domain.MergePolicy())
+		assert.Equal(t, []CodedValue{{"A", "Active"}, {"R", "Retired"}}, domain.CodedValues())
+	}
+
+	domain = ds.FieldDomain("diameter")
+	if assert.False(t, domain.IsNull()) {
+		assert.Equal(t, FDT_Range, domain.Type())
+		assert.Equal(t, &RangeBound{Value: 0.0, Inclusive: true}, domain.RangeMin())
+		assert.Nil(t, domain.RangeMax())
+	}
+
+	assert.Equal(t, "[A-Z]*", ds.FieldDomain("code").Glob())
+	assert.True(t, ds.FieldDomain("missing").IsNull())
+
+	assert.NoError(t, ds.DeleteFieldDomain("code"))
+	assert.Error(t, ds.DeleteFieldDomain("code"))
+	assert.Len(t, ds.FieldDomainNames(nil), 2)
+
+	fd := CreateFieldDefinition("status", FT_String)
+	defer fd.Destroy()
+	assert.Equal(t, "", fd.DomainName())
+	assert.NoError(t, fd.SetDomainName("status"))
+	assert.Equal(t, "status", fd.DomainName())
+}
@@ -0,0 +1,633 @@
+package gdal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Streaming GeoJSON                                               */
+/* -------------------------------------------------------------------- */
+
+// GeoJSONReader reads features from a GeoJSON FeatureCollection, or from a
+// GeoJSON text sequence of features (GeoJSONSeq, either newline delimited
+// or with RFC 8142 record separators), without buffering the whole input.
+// Features are read one at a time, so even a single FeatureCollection
+// streams from its "features" array.
+type GeoJSONReader struct {
+	dec        *json.Decoder
+	definition FeatureDefinition
+	inferred   bool
+	started    bool
+	collection bool
+	done       bool
+	pending    *geoJSONFeature
+	// inferred fields that have only held nulls so far
+	untyped map[string]bool
+	// properties missing from a given definition, in order of appearance
+	unknown     []string
+	unknownSeen map[string]bool
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         json.RawMessage `json:"id"`
+	Geometry   json.RawMessage `json:"geometry"`
+	Properties json.RawMessage `json:"properties"`
+}
+
+// Create a reader of the GeoJSON features in r.  Properties are mapped by
+// name to the fields of definition; those it lacks are skipped and listed
+// by UnknownProperties.
+//
+// If definition is null, one is inferred from the properties of the
+// features as they are read, and Close must be called to release it.  The
+// definition is replaced by a new one when a later feature has a property
+// not seen before, a non-null value for a property that has only been null
+// so far, or a non-integral number for an integer property, which is
+// promoted to a real.  Features keep the definition they were read with.
+func NewGeoJSONReader(r io.Reader, definition FeatureDefinition) *GeoJSONReader {
+	dec := json.NewDecoder(recordSeparatorFilter{r})
+	dec.UseNumber()
+	return &GeoJSONReader{
+		dec:         dec,
+		definition:  definition,
+		inferred:    definition.IsNull(),
+		untyped:     make(map[string]bool),
+		unknownSeen: make(map[string]bool),
+	}
+}
+
+// Fetch the feature definition of the features read.  If it is inferred,
+// it is null until the first feature is read, and is that of the last
+// feature read.
+func (r *GeoJSONReader) Definition() FeatureDefinition {
+	return r.definition
+}
+
+// Return the names of the properties read so far that are not fields of
+// the definition given to NewGeoJSONReader, and so were skipped
+func (r *GeoJSONReader) UnknownProperties() []string {
+	return r.unknown
+}
+
+// Release the inferred feature definition, if any.  Features already read
+// keep their own reference to it.
+func (r *GeoJSONReader) Close() {
+	if r.inferred && !r.definition.IsNull() {
+		r.definition.Release()
+		r.definition = FeatureDefinition{}
+	}
+	r.done = true
+}
+
+// Read the next feature, which the caller must destroy.  Returns io.EOF
+// once all the features have been read.  An error for a feature whose
+// properties do not match the definition does not end the stream, and
+// reading may continue with the next feature.
+func (r *GeoJSONReader) Next() (Feature, error) {
+	f, err := r.nextObject()
+	if err != nil {
+		return Feature{}, err
+	}
+	names, values, err := jsonObjectMembers(f.Properties)
+	if err != nil {
+		return Feature{}, err
+	}
+	if r.inferred {
+		r.updateDefinition(names, values)
+	} else {
+		for _, name := range names {
+			if !r.unknownSeen[name] && r.definition.FieldIndex(name) < 0 {
+				r.unknownSeen[name] = true
+				r.unknown = append(r.unknown, name)
+			}
+		}
+	}
+	return r.feature(f, names, values)
+}
+
+func (r *GeoJSONReader) nextObject() (*geoJSONFeature, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	if !r.started {
+		r.started = true
+		if err := r.start(); err != nil {
+			r.done = true
+			return nil, err
+		}
+	}
+	if r.pending != nil {
+		f := r.pending
+		r.pending = nil
+		return f, nil
+	}
+
+	var f geoJSONFeature
+	if r.collection {
+		if !r.dec.More() {
+			r.done = true
+			return nil, io.EOF
+		}
+		if err := r.dec.Decode(&f); err != nil {
+			r.done = true
+			return nil, fmt.Errorf("%s: %v", ErrCorruptData, err)
+		}
+		return &f, nil
+	}
+	if err := r.dec.Decode(&f); err != nil {
+		r.done = true
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%s: %v", ErrCorruptData, err)
+	}
+	if f.Type != "Feature" {
+		r.done = true
+		return nil, fmt.Errorf("%s: expected a GeoJSON Feature, got %q", ErrCorruptData, f.Type)
+	}
+	return &f, nil
+}
+
+// Read the first top level object up to its "features" array if it is a
+// FeatureCollection, or entirely if it is the first feature of a sequence
+func (r *GeoJSONReader) start() error {
+	tok, err := r.dec.Token()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", ErrCorruptData, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("%s: expected a GeoJSON object", ErrCorruptData)
+	}
+
+	members := make(map[string]json.RawMessage)
+	for r.dec.More() {
+		tok, err := r.dec.Token()
+		if err != nil {
+			return fmt.Errorf("%s: %v", ErrCorruptData, err)
+		}
+		key, _ := tok.(string)
+		if key == "features" {
+			tok, err := r.dec.Token()
+			if err != nil {
+				return fmt.Errorf("%s: %v", ErrCorruptData, err)
+			}
+			if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+				return fmt.Errorf("%s: features is not an array", ErrCorruptData)
+			}
+			r.collection = true
+			return nil
+		}
+		var value json.RawMessage
+		if err := r.dec.Decode(&value); err != nil {
+			return fmt.Errorf("%s: %v", ErrCorruptData, err)
+		}
+		members[key] = value
+	}
+	if _, err := r.dec.Token(); err != nil {
+		return fmt.Errorf("%s: %v", ErrCorruptData, err)
+	}
+
+	var typ string
+	_ = json.Unmarshal(members["type"], &typ)
+	switch typ {
+	case "FeatureCollection":
+		// a collection without features
+		return io.EOF
+	case "Feature":
+		r.pending = &geoJSONFeature{
+			Type:       typ,
+			ID:         members["id"],
+			Geometry:   members["geometry"],
+			Properties: members["properties"],
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: expected a GeoJSON Feature or FeatureCollection, got %q", ErrCorruptData, typ)
+}
+
+// Extend the inferred definition with the properties of a feature,
+// replacing it if a field is added or changes type
+func (r *GeoJSONReader) updateDefinition(names []string, values []json.RawMessage) {
+	type fieldSpec struct {
+		name      string
+		fieldType FieldType
+		subType   FieldSubType
+	}
+	var fields []fieldSpec
+	positions := make(map[string]int)
+	if !r.definition.IsNull() {
+		for i := 0; i < r.definition.FieldCount(); i++ {
+			fd := r.definition.FieldDefinition(i)
+			fields = append(fields, fieldSpec{fd.Name(), fd.Type(), fd.SubType()})
+			positions[fd.Name()] = i
+		}
+	}
+	changed := r.definition.IsNull()
+	for i, name := range names {
+		value := values[i]
+		kind := jsonKind(value)
+		pos, ok := positions[name]
+		if !ok {
+			fieldType, subType := jsonFieldType(value)
+			positions[name] = len(fields)
+			fields = append(fields, fieldSpec{name, fieldType, subType})
+			r.untyped[name] = kind == 'u'
+			changed = true
+			continue
+		}
+		switch {
+		case kind == 'u':
+		case r.untyped[name]:
+			fields[pos].fieldType, fields[pos].subType = jsonFieldType(value)
+			r.untyped[name] = false
+			changed = true
+		case fields[pos].fieldType == FT_Integer64 && kind == 'n':
+			if _, err := strconv.ParseInt(string(value), 10, 64); err != nil {
+				fields[pos].fieldType = FT_Real
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return
+	}
+
+	definition := CreateFeatureDefinition("features")
+	definition.Reference()
+	for _, spec := range fields {
+		field := CreateFieldDefinition(spec.name, spec.fieldType)
+		field.SetSubType(spec.subType)
+		definition.AddFieldDefinition(field)
+		field.Destroy()
+	}
+	if !r.definition.IsNull() {
+		r.definition.Release()
+	}
+	r.definition = definition
+}
+
+// Return the field type to store a JSON value in.  Nulls are stored as
+// strings until a non-null value is seen.
+func jsonFieldType(value json.RawMessage) (FieldType, FieldSubType) {
+	switch jsonKind(value) {
+	case 'n':
+		if _, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return FT_Integer64, FST_None
+		}
+		return FT_Real, FST_None
+	case 't', 'f':
+		return FT_Integer, FST_Boolean
+	case '[', '{':
+		return FT_String, FST_JSON
+	}
+	return FT_String, FST_None
+}
+
+func (r *GeoJSONReader) feature(f *geoJSONFeature, names []string, values []json.RawMessage) (Feature, error) {
+	feature := r.definition.Create()
+	if jsonKind(f.ID) == 'n' {
+		if fid, err := strconv.ParseInt(string(f.ID), 10, 64); err == nil {
+			feature.SetFID(fid)
+		}
+	}
+	if kind := jsonKind(f.Geometry); kind != 0 && kind != 'u' {
+		geom, err := ParseJSON(string(f.Geometry))
+		if err != nil {
+			feature.Destroy()
+			return Feature{}, err
+		}
+		feature.SetGeometryDirectly(geom)
+	}
+
+	for i, name := range names {
+		index := feature.FieldIndex(name)
+		if index < 0 {
+			continue
+		}
+		if err := setFieldFromJSON(feature, index, values[i]); err != nil {
+			feature.Destroy()
+			return Feature{}, fmt.Errorf("property %q: %v", name, err)
+		}
+	}
+	return feature, nil
+}
+
+// Set a field from a JSON value, according to the field type
+func setFieldFromJSON(feature Feature, index int, value json.RawMessage) error {
+	kind := jsonKind(value)
+	if kind == 'u' {
+		feature.SetFieldNull(index)
+		return nil
+	}
+	fieldDefn := feature.FieldDefinition(index)
+	switch fieldDefn.Type() {
+	case FT_Integer, FT_Integer64, FT_Real:
+		switch kind {
+		case 't', 'f':
+			feature.SetFieldBool(index, kind == 't')
+		case 'n':
+			if fieldDefn.Type() == FT_Real {
+				v, err := strconv.ParseFloat(string(value), 64)
+				if err != nil {
+					return err
+				}
+				feature.SetFieldFloat64(index, v)
+			} else {
+				v, err := strconv.ParseInt(string(value), 10, 64)
+				if err != nil {
+					// accept integral values written as reals, such as 1.0
+					f, ferr := strconv.ParseFloat(string(value), 64)
+					if ferr != nil || f != math.Trunc(f) {
+						return err
+					}
+					v = int64(f)
+				}
+				feature.SetFieldInteger64(index, v)
+			}
+		default:
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return fmt.Errorf("cannot set a %s field from %s", fieldDefn.Type().Name(), value)
+			}
+			feature.SetFieldString(index, s)
+		}
+	case FT_IntegerList, FT_Integer64List:
+		var list []int64
+		if err := json.Unmarshal(value, &list); err != nil {
+			return err
+		}
+		feature.SetFieldInteger64List(index, list)
+	case FT_RealList:
+		var list []float64
+		if err := json.Unmarshal(value, &list); err != nil {
+			return err
+		}
+		feature.SetFieldFloat64List(index, list)
+	case FT_StringList:
+		var list []string
+		if err := json.Unmarshal(value, &list); err != nil {
+			return err
+		}
+		feature.SetFieldStringList(index, list)
+	default:
+		// strings are unquoted, other values kept as JSON text
+		if kind == '"' {
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return err
+			}
+			feature.SetFieldString(index, s)
+		} else {
+			feature.SetFieldString(index, string(value))
+		}
+	}
+	return nil
+}
+
+// Return the members of a JSON object in order; a null or missing object
+// has none
+func jsonObjectMembers(raw json.RawMessage) ([]string, []json.RawMessage, error) {
+	if kind := jsonKind(raw); kind == 0 || kind == 'u' {
+		return nil, nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("%s: properties is not an object", ErrCorruptData)
+	}
+	var names []string
+	var values []json.RawMessage
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", ErrCorruptData, err)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", ErrCorruptData, err)
+		}
+		names = append(names, tok.(string))
+		values = append(values, value)
+	}
+	return names, values, nil
+}
+
+// Return the kind of a JSON value from its first byte: '"', '{', '[', 't',
+// 'f', 'n' for numbers, 'u' for null, or 0 when empty
+func jsonKind(raw json.RawMessage) byte {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	if len(raw) == 0 {
+		return 0
+	}
+	switch c := raw[0]; c {
+	case '"', '{', '[', 't', 'f':
+		return c
+	case 'n':
+		return 'u'
+	}
+	return 'n'
+}
+
+// recordSeparatorFilter drops the RFC 8142 record separators that start
+// each text of a GeoJSON text sequence
+type recordSeparatorFilter struct {
+	r io.Reader
+}
+
+func (f recordSeparatorFilter) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	j := 0
+	for _, b := range p[:n] {
+		if b != 0x1e {
+			p[j] = b
+			j++
+		}
+	}
+	return j, err
+}
+
+// GeoJSONWriter writes features as a GeoJSON FeatureCollection, or as a
+// newline delimited GeoJSON text sequence.  Output is buffered until Flush
+// or Close is called, but the features are not held in memory.
+type GeoJSONWriter struct {
+	w       *bufio.Writer
+	seq     bool
+	options []string
+	count   int
+	closed  bool
+}
+
+// Create a writer of GeoJSON features to w, as a text sequence if seq is
+// set.  Options are passed to Geometry.ToJSON_ex, which honors
+// COORDINATE_PRECISION and SIGNIFICANT_FIGURES.  RFC 7946 output is not
+// supported: geometries are written as they are, without reprojection to
+// WGS 84, ring winding or antimeridian handling, so transform them first
+// or use the GeoJSON driver with RFC7946=YES when this matters.  Close must
+// be called to complete the output.
+func NewGeoJSONWriter(w io.Writer, seq bool, options []string) *GeoJSONWriter {
+	return &GeoJSONWriter{
+		w:       bufio.NewWriter(w),
+		seq:     seq,
+		options: options,
+	}
+}
+
+// Write a feature
+func (w *GeoJSONWriter) WriteFeature(feature Feature) error {
+	if w.closed {
+		return fmt.Errorf("%s: GeoJSON writer is closed", ErrIllegal)
+	}
+	var buf bytes.Buffer
+	if err := w.encodeFeature(&buf, feature); err != nil {
+		return err
+	}
+	switch {
+	case w.seq:
+	case w.count == 0:
+		w.w.WriteString(`{"type":"FeatureCollection","features":[` + "\n")
+	default:
+		w.w.WriteString(",\n")
+	}
+	w.count++
+	if _, err := buf.WriteTo(w.w); err != nil {
+		return err
+	}
+	if w.seq {
+		return w.w.WriteByte('\n')
+	}
+	return nil
+}
+
+// Write the buffered output to the underlying writer, for instance to let
+// the client of a streamed response see the features written so far
+func (w *GeoJSONWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// Write all the features of a layer, honoring its filters
+func (w *GeoJSONWriter) WriteLayer(layer Layer) error {
+	return layer.ForEachFeature(w.WriteFeature)
+}
+
+// Complete the output.  Closing does not close the underlying writer.
+func (w *GeoJSONWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if !w.seq {
+		if w.count == 0 {
+			w.w.WriteString(`{"type":"FeatureCollection","features":[`)
+		} else {
+			w.w.WriteString("\n")
+		}
+		w.w.WriteString("]}\n")
+	}
+	return w.w.Flush()
+}
+
+func (w *GeoJSONWriter) encodeFeature(buf *bytes.Buffer, feature Feature) error {
+	buf.WriteString(`{"type":"Feature"`)
+	if fid := feature.FID(); fid >= 0 {
+		fmt.Fprintf(buf, `,"id":%d`, fid)
+	}
+	buf.WriteString(`,"geometry":`)
+	if geom := feature.Geometry(); geom.IsNull() {
+		buf.WriteString("null")
+	} else {
+		buf.WriteString(geom.ToJSON_ex(w.options))
+	}
+	buf.WriteString(`,"properties":{`)
+	for i := 0; i < feature.FieldCount(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fieldDefn := feature.FieldDefinition(i)
+		name, _ := json.Marshal(fieldDefn.Name())
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := fieldJSON(feature, i, fieldDefn)
+		if err != nil {
+			return fmt.Errorf("field %s: %v", name, err)
+		}
+		buf.Write(value)
+	}
+	buf.WriteString("}}")
+	return nil
+}
+
+// Encode a field value as JSON
+func fieldJSON(feature Feature, index int, fieldDefn FieldDefinition) ([]byte, error) {
+	if !feature.IsFieldSetAndNotNull(index) {
+		return []byte("null"), nil
+	}
+	switch fieldDefn.Type() {
+	case FT_Integer:
+		if fieldDefn.SubType() == FST_Boolean {
+			return json.Marshal(feature.FieldAsBool(index))
+		}
+		return json.Marshal(feature.FieldAsInteger(index))
+	case FT_Integer64:
+		return json.Marshal(feature.FieldAsInteger64(index))
+	case FT_Real:
+		v := feature.FieldAsFloat64(index)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return []byte("null"), nil
+		}
+		return json.Marshal(v)
+	case FT_String:
+		s := feature.FieldAsString(index)
+		if fieldDefn.SubType() == FST_JSON && json.Valid([]byte(s)) {
+			return []byte(s), nil
+		}
+		return json.Marshal(s)
+	case FT_IntegerList:
+		return json.Marshal(append([]int(nil), feature.FieldAsIntegerList(index)...))
+	case FT_Integer64List:
+		return json.Marshal(append([]int64(nil), feature.FieldAsInteger64List(index)...))
+	case FT_RealList:
+		list := append([]float64(nil), feature.FieldAsFloat64List(index)...)
+		values := make([]interface{}, len(list))
+		for i, v := range list {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				values[i] = v
+			}
+		}
+		return json.Marshal(values)
+	case FT_StringList:
+		return json.Marshal(feature.FieldAsStringList(index))
+	case FT_Binary:
+		return json.Marshal(append([]byte(nil), feature.FieldAsBinary(index)...))
+	case FT_Date, FT_Time, FT_DateTime:
+		return json.Marshal(isoDateTime(feature.FieldAsString(index)))
+	}
+	return json.Marshal(feature.FieldAsString(index))
+}
+
+// Convert a date, time or date and time formatted by OGR, such as
+// "2020/01/02 10:00:00+02", to ISO 8601
+func isoDateTime(s string) string {
+	s = strings.Replace(s, "/", "-", 2)
+	s = strings.Replace(s, " ", "T", 1)
+	// time zones follow the date part and are written as +HH or +HHMM
+	if i := strings.LastIndexAny(s, "+-"); i > len("2006-01-02") {
+		switch len(s) - i - 1 {
+		case 2:
+			s += ":00"
+		case 4:
+			s = s[:i+3] + ":" + s[i+3:]
+		}
+	}
+	return s
+}
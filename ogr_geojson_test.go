@@ -0,0 +1,238 @@
+package gdal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoJSONReaderCollection(t *testing.T) {
+	definition := CreateFeatureDefinition("places")
+	definition.Reference()
+	defer definition.Release()
+	name := CreateFieldDefinition("name", FT_String)
+	definition.AddFieldDefinition(name)
+	name.Destroy()
+	population := CreateFieldDefinition("population", FT_Integer64)
+	definition.AddFieldDefinition(population)
+	population.Destroy()
+
+	input := `{"type": "FeatureCollection", "name": "places", "features": [
+		{"type": "Feature", "id": 7, "geometry": {"type": "Point", "coordinates": [1, 2]},
+		 "properties": {"name": "Alpha", "population": 1200, "extra": true}},
+		{"type": "Feature", "geometry": null, "properties": {"name": null, "population": 3.0}}
+	]}`
+	reader := NewGeoJSONReader(strings.NewReader(input), definition)
+	defer reader.Close()
+
+	feature, err := reader.Next()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(7), feature.FID())
+	assert.Equal(t, "Alpha", feature.FieldAsString(0))
+	assert.Equal(t, int64(1200), feature.FieldAsInteger64(1))
+	assert.Equal(t, 1.0, feature.Geometry().X(0))
+	feature.Destroy()
+
+	feature, err = reader.Next()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, feature.Geometry().IsNull())
+	assert.True(t, feature.IsFieldNull(0))
+	assert.Equal(t, int64(3), feature.FieldAsInteger64(1))
+	feature.Destroy()
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestGeoJSONReaderSequence(t *testing.T) {
+	input := "\x1e" + `{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}, "properties": {"name": "a", "width": 1.5, "lanes": 2, "oneway": true, "tags": ["x"]}}` + "\n" +
+		"\x1e" + `{"type": "Feature", "geometry": null, "properties": {"name": "b", "width": 3, "lanes": 4, "oneway": false, "tags": null}}` + "\n"
+	reader := NewGeoJSONReader(strings.NewReader(input), FeatureDefinition{})
+	defer reader.Close()
+
+	var features []Feature
+	for {
+		feature, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		features = append(features, feature)
+	}
+	defer destroyFeatures(features)
+	if !assert.Len(t, features, 2) {
+		return
+	}
+
+	definition := reader.Definition()
+	assert.Equal(t, 5, definition.FieldCount())
+	assert.Equal(t, FT_String, definition.FieldDefinition(0).Type())
+	assert.Equal(t, FT_Real, definition.FieldDefinition(1).Type())
+	assert.Equal(t, FT_Integer64, definition.FieldDefinition(2).Type())
+	assert.Equal(t, FST_Boolean, definition.FieldDefinition(3).SubType())
+	assert.Equal(t, FST_JSON, definition.FieldDefinition(4).SubType())
+
+	assert.Equal(t, "a", features[0].FieldAsString(0))
+	assert.Equal(t, 1.5, features[0].FieldAsFloat64(1))
+	assert.True(t, features[0].FieldAsBool(3))
+	assert.Equal(t, `["x"]`, features[0].FieldAsString(4))
+	assert.Equal(t, 3.0, features[1].FieldAsFloat64(1))
+	assert.False(t, features[1].FieldAsBool(3))
+}
+
+func TestGeoJSONReaderSchemaChanges(t *testing.T) {
+	input := `{"type": "Feature", "geometry": null, "properties": {"count": 1, "note": null}}
+{"type": "Feature", "geometry": null, "properties": {"count": 1.5, "note": "late", "extra": true}}
+{"type": "Feature", "geometry": null, "properties": {"count": 2}}`
+	reader := NewGeoJSONReader(strings.NewReader(input), FeatureDefinition{})
+	defer reader.Close()
+
+	first, err := reader.Next()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer first.Destroy()
+	assert.Equal(t, FT_Integer64, first.Definition().FieldDefinition(0).Type())
+
+	second, err := reader.Next()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer second.Destroy()
+	definition := second.Definition()
+	assert.Equal(t, 3, definition.FieldCount())
+	assert.Equal(t, FT_Real, definition.FieldDefinition(0).Type())
+	assert.Equal(t, 1.5, second.FieldAsFloat64(0))
+	assert.Equal(t, "late", second.FieldAsString(1))
+	assert.Equal(t, FST_Boolean, definition.FieldDefinition(2).SubType())
+
+	// the first feature keeps the definition it was read with
+	assert.Equal(t, int64(1), first.FieldAsInteger64(0))
+	assert.Equal(t, 2, first.Definition().FieldCount())
+
+	third, err := reader.Next()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2.0, third.FieldAsFloat64(0))
+		third.Destroy()
+	}
+	assert.Empty(t, reader.UnknownProperties())
+}
+
+func TestGeoJSONReaderUnknownProperties(t *testing.T) {
+	definition := CreateFeatureDefinition("places")
+	definition.Reference()
+	defer definition.Release()
+	name := CreateFieldDefinition("name", FT_String)
+	definition.AddFieldDefinition(name)
+	name.Destroy()
+
+	input := `{"type": "Feature", "geometry": null, "properties": {"name": "a", "rank": 1}}
+{"type": "Feature", "geometry": null, "properties": {"name": "b", "rank": 2, "kind": "x"}}`
+	reader := NewGeoJSONReader(strings.NewReader(input), definition)
+	defer reader.Close()
+	for {
+		feature, err := reader.Next()
+		if err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		feature.Destroy()
+	}
+	assert.Equal(t, []string{"rank", "kind"}, reader.UnknownProperties())
+}
+
+func TestGeoJSONWriter(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("roads", SpatialReference{}, GT_LineString, nil)
+	name := CreateFieldDefinition("name", FT_String)
+	assert.NoError(t, layer.CreateField(name, true))
+	name.Destroy()
+	width := CreateFieldDefinition("width", FT_Real)
+	assert.NoError(t, layer.CreateField(width, true))
+	width.Destroy()
+
+	for i, wkt := range []string{"LINESTRING (0 0,1 1)", "LINESTRING (2 2,3.123456789 3)"} {
+		feature := layer.Definition().Create()
+		feature.SetFieldString(0, []string{"a", "b"}[i])
+		if i == 0 {
+			feature.SetFieldFloat64(1, 2.5)
+		}
+		geom, _ := CreateFromWKT(wkt, SpatialReference{})
+		feature.SetGeometryDirectly(geom)
+		assert.NoError(t, layer.Create(feature))
+		feature.Destroy()
+	}
+
+	var buf bytes.Buffer
+	writer := NewGeoJSONWriter(&buf, false, []string{"COORDINATE_PRECISION=2"})
+	assert.NoError(t, writer.WriteLayer(layer))
+	assert.NoError(t, writer.Close())
+
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry struct {
+				Type        string      `json:"type"`
+				Coordinates [][]float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if !assert.NoError(t, json.Unmarshal(buf.Bytes(), &collection)) {
+		return
+	}
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	if assert.Len(t, collection.Features, 2) {
+		assert.Equal(t, "a", collection.Features[0].Properties["name"])
+		assert.Equal(t, 2.5, collection.Features[0].Properties["width"])
+		assert.Nil(t, collection.Features[1].Properties["width"])
+		assert.Equal(t, "LineString", collection.Features[1].Geometry.Type)
+		assert.Equal(t, 3.12, collection.Features[1].Geometry.Coordinates[1][0])
+	}
+
+	// a sequence reads back through GeoJSONReader
+	buf.Reset()
+	writer = NewGeoJSONWriter(&buf, true, nil)
+	assert.NoError(t, writer.WriteLayer(layer))
+	assert.NoError(t, writer.Close())
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	reader := NewGeoJSONReader(&buf, layer.Definition())
+	defer reader.Close()
+	count := 0
+	for {
+		feature, err := reader.Next()
+		if err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		assert.Equal(t, GT_LineString, feature.Geometry().Type())
+		feature.Destroy()
+		count++
+	}
+	assert.Equal(t, 2, count)
+
+	buf.Reset()
+	writer = NewGeoJSONWriter(&buf, false, nil)
+	assert.NoError(t, writer.Close())
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[]}`, buf.String())
+}
+
+func TestISODateTime(t *testing.T) {
+	assert.Equal(t, "2020-01-02", isoDateTime("2020/01/02"))
+	assert.Equal(t, "2020-01-02T10:00:00", isoDateTime("2020/01/02 10:00:00"))
+	assert.Equal(t, "2020-01-02T10:00:00+02:00", isoDateTime("2020/01/02 10:00:00+02"))
+	assert.Equal(t, "2020-01-02T10:00:00.500-05:30", isoDateTime("2020/01/02 10:00:00.500-0530"))
+}
@@ -0,0 +1,358 @@
+package gdal
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+)
+
+/* -------------------------------------------------------------------- */
+/*      In-memory spatial index                                         */
+/* -------------------------------------------------------------------- */
+
+// Maximum number of children of an index node
+const spatialIndexNodeSize = 16
+
+// SpatialIndex is an in-memory R-tree over the envelopes of the features of
+// a layer, bulk loaded with the Sort-Tile-Recursive algorithm.  It keeps a
+// copy of each feature geometry, against which queries refine their
+// candidates; only queries that return features fetch them from the layer
+// by FID.  The index is not updated when the layer changes, must not be
+// used from several goroutines at once, and must be destroyed to release
+// its geometries.
+type SpatialIndex struct {
+	layer Layer
+	root  *indexNode
+	geoms []Geometry
+}
+
+type indexRect struct {
+	minX, minY, maxX, maxY float64
+}
+
+type indexEntry struct {
+	rect indexRect
+	fid  int64
+	geom Geometry
+}
+
+type indexNode struct {
+	rect     indexRect
+	children []*indexNode
+	entries  []indexEntry
+}
+
+// SpatialPredicate tests a feature geometry against a query geometry.
+// Method expressions such as Geometry.Intersects or Geometry.Within can be
+// used directly.
+type SpatialPredicate func(featureGeom, queryGeom Geometry) bool
+
+// Build a spatial index over the features of the layer.  Features without
+// a geometry, or with an empty one, are left out.
+func BuildSpatialIndex(layer Layer) (*SpatialIndex, error) {
+	var entries []indexEntry
+	var geoms []Geometry
+	err := layer.ForEachFeature(func(feature Feature) error {
+		geom := feature.Geometry()
+		if geom.IsNull() || geom.IsEmpty() {
+			return nil
+		}
+		geom = geom.Clone()
+		geoms = append(geoms, geom)
+		entries = append(entries, indexEntry{
+			rect: rectFromEnvelope(geom.Envelope()),
+			fid:  feature.FID(),
+			geom: geom,
+		})
+		return nil
+	})
+	if err != nil {
+		for _, geom := range geoms {
+			geom.Destroy()
+		}
+		return nil, err
+	}
+	return &SpatialIndex{
+		layer: layer,
+		root:  packIndex(entries),
+		geoms: geoms,
+	}, nil
+}
+
+// Destroy the geometries held by the index
+func (idx *SpatialIndex) Destroy() {
+	for _, geom := range idx.geoms {
+		geom.Destroy()
+	}
+	idx.root = nil
+	idx.geoms = nil
+}
+
+// Return the number of features in the index
+func (idx *SpatialIndex) Len() int {
+	return len(idx.geoms)
+}
+
+// Return the identifiers of the features whose envelope intersects env
+func (idx *SpatialIndex) Search(env Envelope) []int64 {
+	var fids []int64
+	idx.search(rectFromEnvelope(env), func(entry *indexEntry) {
+		fids = append(fids, entry.fid)
+	})
+	return fids
+}
+
+func (idx *SpatialIndex) search(rect indexRect, match func(*indexEntry)) {
+	if idx.root != nil {
+		idx.root.search(rect, match)
+	}
+}
+
+// Return copies of the features whose envelope intersects env.  The
+// caller must destroy them.
+func (idx *SpatialIndex) SearchFeatures(env Envelope) ([]Feature, error) {
+	return idx.features(idx.Search(env))
+}
+
+// Return the identifiers of the features whose envelope intersects the
+// envelope of geom, and whose geometry satisfies pred against geom
+func (idx *SpatialIndex) Query(geom Geometry, pred SpatialPredicate) []int64 {
+	return idx.query(geom, pred)
+}
+
+// Return copies of the features whose envelope intersects the envelope of
+// geom, and whose geometry satisfies pred against geom.  The caller must
+// destroy them.
+func (idx *SpatialIndex) QueryFeatures(geom Geometry, pred SpatialPredicate) ([]Feature, error) {
+	return idx.features(idx.query(geom, pred))
+}
+
+func (idx *SpatialIndex) query(geom Geometry, pred SpatialPredicate) []int64 {
+	var fids []int64
+	idx.search(rectFromEnvelope(geom.Envelope()), func(entry *indexEntry) {
+		if pred(entry.geom, geom) {
+			fids = append(fids, entry.fid)
+		}
+	})
+	return fids
+}
+
+// Return the identifiers of the k features nearest to geom, closest first.
+// Distances are measured between geometries, using envelopes only to
+// order the search.
+func (idx *SpatialIndex) Nearest(geom Geometry, k int) []int64 {
+	if idx.root == nil || k <= 0 {
+		return nil
+	}
+	target := rectFromEnvelope(geom.Envelope())
+
+	queue := &nearestQueue{{dist: idx.root.rect.distance(target), node: idx.root}}
+	var fids []int64
+	for queue.Len() > 0 && len(fids) < k {
+		item := heap.Pop(queue).(nearestItem)
+		switch {
+		case item.node != nil:
+			for _, child := range item.node.children {
+				heap.Push(queue, nearestItem{dist: child.rect.distance(target), node: child})
+			}
+			for i := range item.node.entries {
+				entry := &item.node.entries[i]
+				heap.Push(queue, nearestItem{dist: entry.rect.distance(target), entry: entry})
+			}
+		case item.exact:
+			fids = append(fids, item.entry.fid)
+		default:
+			// the envelope distance is a lower bound, so queue the entry
+			// again with its exact distance
+			dist := item.entry.geom.Distance(geom)
+			heap.Push(queue, nearestItem{dist: dist, entry: item.entry, exact: true})
+		}
+	}
+	return fids
+}
+
+// Fetch a feature from the layer by identifier
+func (idx *SpatialIndex) feature(fid int64) (Feature, error) {
+	feature := idx.layer.Feature(fid)
+	if feature.IsNull() {
+		return Feature{}, fmt.Errorf("%s: fid %d", ErrNonExistingFeature, fid)
+	}
+	return feature, nil
+}
+
+// Fetch features from the layer by identifier
+func (idx *SpatialIndex) features(fids []int64) ([]Feature, error) {
+	features := make([]Feature, 0, len(fids))
+	for _, fid := range fids {
+		feature, err := idx.feature(fid)
+		if err != nil {
+			destroyFeatures(features)
+			return nil, err
+		}
+		features = append(features, feature)
+	}
+	return features, nil
+}
+
+func destroyFeatures(features []Feature) {
+	for _, feature := range features {
+		feature.Destroy()
+	}
+}
+
+func (node *indexNode) search(rect indexRect, match func(*indexEntry)) {
+	for _, child := range node.children {
+		if child.rect.intersects(rect) {
+			child.search(rect, match)
+		}
+	}
+	for i := range node.entries {
+		if node.entries[i].rect.intersects(rect) {
+			match(&node.entries[i])
+		}
+	}
+}
+
+// Pack entries into a tree, bottom up
+func packIndex(entries []indexEntry) *indexNode {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	rects := make([]indexRect, len(entries))
+	for i, entry := range entries {
+		rects[i] = entry.rect
+	}
+	var nodes []*indexNode
+	for _, group := range strGroups(rects) {
+		node := &indexNode{entries: make([]indexEntry, len(group))}
+		for i, j := range group {
+			node.entries[i] = entries[j]
+		}
+		node.rect = node.entries[0].rect
+		for _, entry := range node.entries[1:] {
+			node.rect = node.rect.union(entry.rect)
+		}
+		nodes = append(nodes, node)
+	}
+
+	for len(nodes) > 1 {
+		rects = rects[:len(nodes)]
+		for i, node := range nodes {
+			rects[i] = node.rect
+		}
+		var parents []*indexNode
+		for _, group := range strGroups(rects) {
+			parent := &indexNode{children: make([]*indexNode, len(group))}
+			for i, j := range group {
+				parent.children[i] = nodes[j]
+			}
+			parent.rect = parent.children[0].rect
+			for _, child := range parent.children[1:] {
+				parent.rect = parent.rect.union(child.rect)
+			}
+			parents = append(parents, parent)
+		}
+		nodes = parents
+	}
+	return nodes[0]
+}
+
+// Split rectangles into groups of at most spatialIndexNodeSize: sort them
+// by center x into vertical slices, then each slice by center y
+func strGroups(rects []indexRect) [][]int {
+	order := make([]int, len(rects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return rects[order[a]].centerX() < rects[order[b]].centerX()
+	})
+
+	leaves := int(math.Ceil(float64(len(rects)) / spatialIndexNodeSize))
+	slices := int(math.Ceil(math.Sqrt(float64(leaves))))
+	sliceSize := slices * spatialIndexNodeSize
+
+	var groups [][]int
+	for start := 0; start < len(order); start += sliceSize {
+		end := start + sliceSize
+		if end > len(order) {
+			end = len(order)
+		}
+		slice := order[start:end]
+		sort.Slice(slice, func(a, b int) bool {
+			return rects[slice[a]].centerY() < rects[slice[b]].centerY()
+		})
+		for i := 0; i < len(slice); i += spatialIndexNodeSize {
+			j := i + spatialIndexNodeSize
+			if j > len(slice) {
+				j = len(slice)
+			}
+			groups = append(groups, slice[i:j])
+		}
+	}
+	return groups
+}
+
+func rectFromEnvelope(env Envelope) indexRect {
+	return indexRect{env.MinX(), env.MinY(), env.MaxX(), env.MaxY()}
+}
+
+func (r indexRect) centerX() float64 {
+	return (r.minX + r.maxX) / 2
+}
+
+func (r indexRect) centerY() float64 {
+	return (r.minY + r.maxY) / 2
+}
+
+func (r indexRect) intersects(other indexRect) bool {
+	return r.minX <= other.maxX && r.maxX >= other.minX &&
+		r.minY <= other.maxY && r.maxY >= other.minY
+}
+
+func (r indexRect) union(other indexRect) indexRect {
+	return indexRect{
+		math.Min(r.minX, other.minX), math.Min(r.minY, other.minY),
+		math.Max(r.maxX, other.maxX), math.Max(r.maxY, other.maxY),
+	}
+}
+
+// Return the distance between two rectangles, 0 if they intersect
+func (r indexRect) distance(other indexRect) float64 {
+	dx := math.Max(0, math.Max(r.minX-other.maxX, other.minX-r.maxX))
+	dy := math.Max(0, math.Max(r.minY-other.maxY, other.minY-r.maxY))
+	return math.Hypot(dx, dy)
+}
+
+type nearestItem struct {
+	dist  float64
+	node  *indexNode
+	entry *indexEntry
+	exact bool
+}
+
+// nearestQueue is a min-heap of nodes and entries ordered by distance
+type nearestQueue []nearestItem
+
+func (q nearestQueue) Len() int { return len(q) }
+
+func (q nearestQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	// at equal distance, report exact entries before refining others
+	return q[i].exact && !q[j].exact
+}
+
+func (q nearestQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nearestQueue) Push(x interface{}) { *q = append(*q, x.(nearestItem)) }
+
+func (q *nearestQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,73 @@
+package gdal
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpatialIndex(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("points", SpatialReference{}, GT_Point, nil)
+
+	// a 30x30 grid of points, enough for a tree of several levels
+	fids := make(map[[2]int]int64)
+	for x := 0; x < 30; x++ {
+		for y := 0; y < 30; y++ {
+			geom, _ := CreateFromWKT(fmt.Sprintf("POINT (%d %d)", x, y), SpatialReference{})
+			feature := layer.Definition().Create()
+			feature.SetGeometryDirectly(geom)
+			assert.NoError(t, layer.Create(feature))
+			fids[[2]int{x, y}] = feature.FID()
+			feature.Destroy()
+		}
+	}
+	feature := layer.Definition().Create()
+	assert.NoError(t, layer.Create(feature))
+	feature.Destroy()
+
+	idx, err := BuildSpatialIndex(layer)
+	if err != nil {
+		t.Fatalf("BuildSpatialIndex: %v", err)
+	}
+	defer idx.Destroy()
+	assert.Equal(t, 900, idx.Len())
+
+	var env Envelope
+	env.SetMinX(9.5)
+	env.SetMaxX(12)
+	env.SetMinY(0)
+	env.SetMaxY(1)
+	found := idx.Search(env)
+	sort.Slice(found, func(i, j int) bool { return found[i] < found[j] })
+	expected := []int64{fids[[2]int{10, 0}], fids[[2]int{10, 1}], fids[[2]int{11, 0}],
+		fids[[2]int{11, 1}], fids[[2]int{12, 0}], fids[[2]int{12, 1}]}
+	sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+	assert.Equal(t, expected, found)
+
+	features, err := idx.SearchFeatures(env)
+	assert.NoError(t, err)
+	assert.Len(t, features, 6)
+	destroyFeatures(features)
+
+	// a triangle whose envelope holds 16 points, of which 10 touch it
+	triangle, _ := CreateFromWKT("POLYGON ((0 0, 3 0, 0 3, 0 0))", SpatialReference{})
+	defer triangle.Destroy()
+	assert.Len(t, idx.Search(triangle.Envelope()), 16)
+	assert.Len(t, idx.Query(triangle, Geometry.Intersects), 10)
+
+	features, err = idx.QueryFeatures(triangle, func(featureGeom, queryGeom Geometry) bool {
+		return featureGeom.X(0) == 0
+	})
+	assert.NoError(t, err)
+	assert.Len(t, features, 4)
+	destroyFeatures(features)
+
+	target, _ := CreateFromWKT("POINT (20.2 7.1)", SpatialReference{})
+	defer target.Destroy()
+	nearest := idx.Nearest(target, 3)
+	assert.Equal(t, []int64{fids[[2]int{20, 7}], fids[[2]int{21, 7}], fids[[2]int{20, 8}]}, nearest)
+}
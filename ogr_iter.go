@@ -0,0 +1,35 @@
+//go:build go1.23
+// +build go1.23
+
+package gdal
+
+import "iter"
+
+// Return a range-over-func sequence of the features of this layer, for use
+// as
+//
+//	for feature, err := range layer.FeaturesSeq() {
+//		if err != nil {
+//			...
+//		}
+//		...
+//	}
+//
+// Reading is reset first.  Each feature is destroyed once the loop body
+// returns, including when the loop is left early with break or return.  A
+// read error is yielded once, with an empty feature, and ends the
+// sequence.
+func (layer Layer) FeaturesSeq() iter.Seq2[Feature, error] {
+	return func(yield func(Feature, error) bool) {
+		it := layer.Features()
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Feature(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(Feature{}, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+//go:build go1.23
+// +build go1.23
+
+package gdal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerFeaturesSeq(t *testing.T) {
+	ds, err := OpenEx("testdata/test.shp", OFReadOnly|OFVector, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer ds.Close()
+	layer := ds.LayerByIndex(0)
+	expected, _ := layer.FeatureCount(true)
+
+	count := 0
+	for feature, err := range layer.FeaturesSeq() {
+		assert.NoError(t, err)
+		assert.False(t, feature.IsNull())
+		count++
+	}
+	assert.Equal(t, expected, count)
+
+	for range layer.FeaturesSeq() {
+		break
+	}
+}
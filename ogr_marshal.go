@@ -0,0 +1,502 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_ogr_wkb.h"
+*/
+import "C"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Struct marshaling                                               */
+/* -------------------------------------------------------------------- */
+
+// Struct fields are mapped to feature fields with an `ogr` tag holding the
+// field name, optionally followed by comma separated options:
+//
+//	type Parcel struct {
+//		ID       int64     `ogr:",fid"`
+//		Name     string    `ogr:"name"`
+//		Area     *float64  `ogr:"area"`
+//		Surveyed time.Time `ogr:"surveyed"`
+//		Shape    Geometry  `ogr:",geometry"`
+//	}
+//
+// Untagged fields and fields tagged "-" are ignored.  The supported field
+// types are the integer kinds, float32 and float64, bool, string, []byte,
+// time.Time, slices of int, int32, int64, float64 and string, and pointers
+// to any of these, where a nil pointer stands for a null field.
+//
+// The "geometry" option maps a Geometry, or a []byte holding WKB, to the
+// feature geometry.  The "fid" option maps an integer field to the feature
+// identifier; it is read by Scan and filled in by Layer.CreateFromStruct
+// once the driver has assigned it.
+
+type structField struct {
+	index    []int
+	name     string
+	geometry bool
+	fid      bool
+}
+
+var structFieldsCache sync.Map
+
+var (
+	geometryType = reflect.TypeOf(Geometry{})
+	timeType     = reflect.TypeOf(time.Time{})
+	bytesType    = reflect.TypeOf([]byte(nil))
+)
+
+// Return the tagged fields of a struct type
+func structFields(t reflect.Type) ([]structField, error) {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField), nil
+	}
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("ogr")
+		if !ok || tag == "-" || f.PkgPath != "" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		sf := structField{index: f.Index, name: parts[0]}
+		for _, option := range parts[1:] {
+			switch option {
+			case "geometry":
+				sf.geometry = true
+			case "fid":
+				sf.fid = true
+			default:
+				return nil, fmt.Errorf("field %s: unknown ogr tag option %q", f.Name, option)
+			}
+		}
+		if sf.geometry && f.Type != geometryType && f.Type != bytesType {
+			return nil, fmt.Errorf("field %s: geometry must be a Geometry or []byte", f.Name)
+		}
+		if sf.fid {
+			switch f.Type.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			default:
+				return nil, fmt.Errorf("field %s: fid must be an integer", f.Name)
+			}
+		}
+		if !sf.geometry && !sf.fid {
+			if sf.name == "" {
+				return nil, fmt.Errorf("field %s: missing ogr field name", f.Name)
+			}
+			if _, err := fieldTypeOf(f.Type); err != nil {
+				return nil, fmt.Errorf("field %s: %v", f.Name, err)
+			}
+		}
+		fields = append(fields, sf)
+	}
+	structFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// Return the OGR field type a Go type is stored as
+func fieldTypeOf(t reflect.Type) (FieldType, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return FT_DateTime, nil
+	case bytesType:
+		return FT_Binary, nil
+	}
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Bool:
+		return FT_Integer, nil
+	case reflect.Int, reflect.Int64, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		return FT_Integer64, nil
+	case reflect.Float32, reflect.Float64:
+		return FT_Real, nil
+	case reflect.String:
+		return FT_String, nil
+	case reflect.Slice:
+		switch t.Elem().Kind() {
+		case reflect.Int32:
+			return FT_IntegerList, nil
+		case reflect.Int, reflect.Int64:
+			return FT_Integer64List, nil
+		case reflect.Float64:
+			return FT_RealList, nil
+		case reflect.String:
+			return FT_StringList, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported type %s", t)
+}
+
+// Return the OGR field subtype a Go type is stored as
+func fieldSubTypeOf(t reflect.Type) FieldSubType {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return FST_Boolean
+	case reflect.Int16:
+		return FST_Int16
+	case reflect.Float32:
+		return FST_Float32
+	}
+	return FST_None
+}
+
+// Return the struct value a pointer or value refers to
+func structValue(v interface{}, settable bool) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	} else if settable {
+		return reflect.Value{}, fmt.Errorf("expected a non-nil pointer to a struct, got %T", v)
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a struct, got %T", v)
+	}
+	return rv, nil
+}
+
+// Create a field on the layer for each tagged field of the struct.  bool,
+// int16 and float32 fields are given the matching field subtype.
+func LayerSchemaFromStruct(layer Layer, v interface{}) error {
+	rv, err := structValue(v, false)
+	if err != nil {
+		return err
+	}
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, sf := range fields {
+		if sf.geometry || sf.fid {
+			continue
+		}
+		t := rv.Type().FieldByIndex(sf.index).Type
+		fieldType, _ := fieldTypeOf(t)
+		fd := CreateFieldDefinition(sf.name, fieldType)
+		fd.SetSubType(fieldSubTypeOf(t))
+		err := layer.CreateField(fd, true)
+		fd.Destroy()
+		if err != nil {
+			return fmt.Errorf("creating field %s: %v", sf.name, err)
+		}
+	}
+	return nil
+}
+
+// Create a new feature in the layer from the tagged fields of a struct.  If
+// v is a pointer, its fid field (if any) is set to the identifier assigned
+// by the driver.
+func (layer Layer) CreateFromStruct(v interface{}) error {
+	feature := layer.Definition().Create()
+	defer feature.Destroy()
+	if err := feature.SetFromStruct(v); err != nil {
+		return err
+	}
+	if err := layer.Create(feature); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return nil
+	}
+	fields, _ := structFields(rv.Elem().Type())
+	for _, sf := range fields {
+		if sf.fid {
+			setInt(rv.Elem().FieldByIndex(sf.index), feature.FID())
+		}
+	}
+	return nil
+}
+
+// Set the fields and geometry of the feature from the tagged fields of a
+// struct.  Nil pointers leave their field unset.
+func (feature Feature) SetFromStruct(v interface{}) error {
+	rv, err := structValue(v, false)
+	if err != nil {
+		return err
+	}
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, sf := range fields {
+		fv := rv.FieldByIndex(sf.index)
+		switch {
+		case sf.fid:
+			continue
+		case sf.geometry:
+			if err := feature.setGeometryFromValue(fv); err != nil {
+				return err
+			}
+		default:
+			index := feature.FieldIndex(sf.name)
+			if index < 0 {
+				return fmt.Errorf("field %s not found", sf.name)
+			}
+			feature.setFieldFromValue(index, fv)
+		}
+	}
+	return nil
+}
+
+// Copy the fields and geometry of the feature into the tagged fields of the
+// struct pointed to by dst.  Null and unset fields set pointers to nil and
+// other types to their zero value.  A Geometry field receives a clone of
+// the feature geometry, which the caller must destroy.
+func (feature Feature) Scan(dst interface{}) error {
+	rv, err := structValue(dst, true)
+	if err != nil {
+		return err
+	}
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, sf := range fields {
+		fv := rv.FieldByIndex(sf.index)
+		switch {
+		case sf.fid:
+			setInt(fv, feature.FID())
+		case sf.geometry:
+			if err := feature.scanGeometry(fv); err != nil {
+				return err
+			}
+		default:
+			index := feature.FieldIndex(sf.name)
+			if index < 0 {
+				return fmt.Errorf("field %s not found", sf.name)
+			}
+			feature.scanField(index, fv)
+		}
+	}
+	return nil
+}
+
+func setInt(fv reflect.Value, val int64) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(val))
+	}
+}
+
+func (feature Feature) scanGeometry(fv reflect.Value) error {
+	geom := feature.Geometry()
+	if fv.Type() == geometryType {
+		if geom.cval == nil {
+			fv.Set(reflect.ValueOf(Geometry{}))
+		} else {
+			fv.Set(reflect.ValueOf(geom.Clone()))
+		}
+		return nil
+	}
+	if geom.cval == nil {
+		fv.SetBytes(nil)
+		return nil
+	}
+	wkb, err := geom.ToWKB()
+	if err != nil {
+		return err
+	}
+	fv.SetBytes(wkb)
+	return nil
+}
+
+func (feature Feature) setGeometryFromValue(fv reflect.Value) error {
+	if fv.Type() == geometryType {
+		return feature.SetGeometry(fv.Interface().(Geometry))
+	}
+	wkb := fv.Bytes()
+	if len(wkb) == 0 {
+		return feature.SetGeometryDirectly(Geometry{})
+	}
+	geom, err := CreateFromWKB(wkb, SpatialReference{}, len(wkb))
+	if err != nil {
+		return err
+	}
+	return feature.SetGeometryDirectly(geom)
+}
+
+func (feature Feature) scanField(index int, fv reflect.Value) {
+	if !feature.IsFieldSetAndNotNull(index) {
+		fv.Set(reflect.Zero(fv.Type()))
+		return
+	}
+	if fv.Kind() == reflect.Ptr {
+		ptr := reflect.New(fv.Type().Elem())
+		feature.scanField(index, ptr.Elem())
+		fv.Set(ptr)
+		return
+	}
+
+	switch fv.Type() {
+	case timeType:
+		fv.Set(reflect.ValueOf(feature.fieldAsTime(index)))
+		return
+	case bytesType:
+		var count C.int
+		p := C.OGR_F_GetFieldAsBinary(feature.cval, C.int(index), &count)
+		fv.SetBytes(C.GoBytes(unsafe.Pointer(p), count))
+		return
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		setInt(fv, feature.FieldAsInteger64(index))
+	case reflect.Bool:
+		fv.SetBool(feature.FieldAsBool(index))
+	case reflect.Float32, reflect.Float64:
+		fv.SetFloat(feature.FieldAsFloat64(index))
+	case reflect.String:
+		fv.SetString(feature.FieldAsString(index))
+	case reflect.Slice:
+		feature.scanList(index, fv)
+	}
+}
+
+func (feature Feature) scanList(index int, fv reflect.Value) {
+	var count C.int
+	switch fv.Type().Elem().Kind() {
+	case reflect.Int32:
+		p := C.OGR_F_GetFieldAsIntegerList(feature.cval, C.int(index), &count)
+		values := (*[math.MaxInt32 / 4]C.int)(unsafe.Pointer(p))
+		list := make([]int32, int(count))
+		for i := range list {
+			list[i] = int32(values[i])
+		}
+		fv.Set(reflect.ValueOf(list).Convert(fv.Type()))
+	case reflect.Int, reflect.Int64:
+		p := C.OGR_F_GetFieldAsInteger64List(feature.cval, C.int(index), &count)
+		values := (*[math.MaxInt32 / 8]C.GIntBig)(unsafe.Pointer(p))
+		list := reflect.MakeSlice(fv.Type(), int(count), int(count))
+		for i := 0; i < int(count); i++ {
+			list.Index(i).SetInt(int64(values[i]))
+		}
+		fv.Set(list)
+	case reflect.Float64:
+		p := C.OGR_F_GetFieldAsDoubleList(feature.cval, C.int(index), &count)
+		values := (*[math.MaxInt32 / 8]C.double)(unsafe.Pointer(p))
+		list := make([]float64, int(count))
+		for i := range list {
+			list[i] = float64(values[i])
+		}
+		fv.Set(reflect.ValueOf(list).Convert(fv.Type()))
+	case reflect.String:
+		fv.Set(reflect.ValueOf(feature.FieldAsStringList(index)).Convert(fv.Type()))
+	}
+}
+
+// Fetch a date/time field, keeping fractional seconds and time zone
+func (feature Feature) fieldAsTime(index int) time.Time {
+	var year, month, day, hour, minute, tzFlag C.int
+	var second C.float
+	C.OGR_F_GetFieldAsDateTimeEx(
+		feature.cval, C.int(index),
+		&year, &month, &day, &hour, &minute, &second, &tzFlag,
+	)
+	loc := time.UTC
+	switch {
+	case tzFlag == 1:
+		loc = time.Local
+	case tzFlag > 1:
+		loc = time.FixedZone("", int(tzFlag-100)*15*60)
+	}
+	sec := float64(second)
+	whole := math.Floor(sec)
+	nsec := int(math.Round((sec - whole) * 1e9))
+	return time.Date(int(year), time.Month(month), int(day), int(hour), int(minute), int(whole), nsec, loc)
+}
+
+// Set a date/time field, keeping fractional seconds and time zone
+func (feature Feature) setFieldTime(index int, t time.Time) {
+	_, offset := t.Zone()
+	second := float64(t.Second()) + float64(t.Nanosecond())/1e9
+	C.OGR_F_SetFieldDateTimeEx(
+		feature.cval, C.int(index),
+		C.int(t.Year()), C.int(t.Month()), C.int(t.Day()),
+		C.int(t.Hour()), C.int(t.Minute()), C.float(second),
+		C.int(100+offset/(15*60)),
+	)
+}
+
+func (feature Feature) setFieldFromValue(index int, fv reflect.Value) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			feature.UnnsetField(index)
+			return
+		}
+		fv = fv.Elem()
+	}
+
+	switch fv.Type() {
+	case timeType:
+		feature.setFieldTime(index, fv.Interface().(time.Time))
+		return
+	case bytesType:
+		data := fv.Bytes()
+		var p unsafe.Pointer
+		if len(data) > 0 {
+			p = unsafe.Pointer(&data[0])
+		}
+		C.OGR_F_SetFieldBinary(feature.cval, C.int(index), C.int(len(data)), p)
+		return
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		feature.SetFieldInteger64(index, fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		feature.SetFieldInteger64(index, int64(fv.Uint()))
+	case reflect.Bool:
+		feature.SetFieldBool(index, fv.Bool())
+	case reflect.Float32, reflect.Float64:
+		feature.SetFieldFloat64(index, fv.Float())
+	case reflect.String:
+		feature.SetFieldString(index, fv.String())
+	case reflect.Slice:
+		feature.setFieldList(index, fv)
+	}
+}
+
+func (feature Feature) setFieldList(index int, fv reflect.Value) {
+	count := fv.Len()
+	switch fv.Type().Elem().Kind() {
+	case reflect.Int32:
+		values := make([]C.int, count+1)
+		for i := 0; i < count; i++ {
+			values[i] = C.int(fv.Index(i).Int())
+		}
+		C.OGR_F_SetFieldIntegerList(feature.cval, C.int(index), C.int(count), &values[0])
+	case reflect.Int, reflect.Int64:
+		values := make([]C.GIntBig, count+1)
+		for i := 0; i < count; i++ {
+			values[i] = C.GIntBig(fv.Index(i).Int())
+		}
+		C.OGR_F_SetFieldInteger64List(feature.cval, C.int(index), C.int(count), &values[0])
+	case reflect.Float64:
+		values := make([]C.double, count+1)
+		for i := 0; i < count; i++ {
+			values[i] = C.double(fv.Index(i).Float())
+		}
+		C.OGR_F_SetFieldDoubleList(feature.cval, C.int(index), C.int(count), &values[0])
+	case reflect.String:
+		feature.SetFieldStringList(index, fv.Convert(reflect.TypeOf([]string(nil))).Interface().([]string))
+	}
+}
@@ -0,0 +1,108 @@
+package gdal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testParcel struct {
+	ID       int64     `ogr:",fid"`
+	Name     string    `ogr:"name"`
+	Units    int       `ogr:"units"`
+	Area     *float64  `ogr:"area"`
+	Surveyed time.Time `ogr:"surveyed"`
+	Tags     []string  `ogr:"tags"`
+	Blob     []byte    `ogr:"blob"`
+	Shape    Geometry  `ogr:",geometry"`
+	Ignored  string
+}
+
+// createMemoryDataset creates an empty in-memory vector dataset
+func createMemoryDataset(t *testing.T) Dataset {
+	driver, err := GetDriverByName("Memory")
+	if err != nil {
+		driver, err = GetDriverByName("MEM")
+	}
+	if err != nil {
+		t.Fatalf("no memory driver: %v", err)
+	}
+	return driver.Create("memory", 0, 0, 0, Unknown, nil)
+}
+
+func TestStructRoundTrip(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("parcels", SpatialReference{}, GT_Polygon, nil)
+	if err := LayerSchemaFromStruct(layer, testParcel{}); err != nil {
+		t.Fatalf("LayerSchemaFromStruct: %v", err)
+	}
+	assert.Equal(t, 6, layer.Definition().FieldCount())
+	assert.Equal(t, FT_Integer64, layer.Definition().FieldDefinition(1).Type())
+
+	shape, _ := CreateFromWKT("POLYGON ((0 0, 1 0, 1 1, 0 0))", SpatialReference{})
+	defer shape.Destroy()
+	area := 0.5
+	surveyed := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	in := testParcel{
+		Name:     "lot 1",
+		Units:    3,
+		Area:     &area,
+		Surveyed: surveyed,
+		Tags:     []string{"a", "b"},
+		Blob:     []byte{1, 2, 3},
+		Shape:    shape,
+	}
+	assert.NoError(t, layer.CreateFromStruct(&in))
+	assert.NoError(t, layer.CreateFromStruct(testParcel{Name: "lot 2"}))
+
+	var out []testParcel
+	err := layer.ForEachFeature(func(feature Feature) error {
+		var p testParcel
+		if err := feature.Scan(&p); err != nil {
+			return err
+		}
+		out = append(out, p)
+		return nil
+	})
+	assert.NoError(t, err)
+	if !assert.Len(t, out, 2) {
+		return
+	}
+
+	assert.Equal(t, in.ID, out[0].ID)
+	assert.Equal(t, "lot 1", out[0].Name)
+	assert.Equal(t, 3, out[0].Units)
+	if assert.NotNil(t, out[0].Area) {
+		assert.Equal(t, 0.5, *out[0].Area)
+	}
+	assert.True(t, surveyed.Equal(out[0].Surveyed))
+	assert.Equal(t, []string{"a", "b"}, out[0].Tags)
+	assert.Equal(t, []byte{1, 2, 3}, out[0].Blob)
+	assert.True(t, shape.Equals(out[0].Shape))
+	out[0].Shape.Destroy()
+
+	assert.Equal(t, "lot 2", out[1].Name)
+	assert.Nil(t, out[1].Area)
+	assert.True(t, out[1].Shape.IsNull())
+}
+
+func TestStructErrors(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("bad", SpatialReference{}, GT_Point, nil)
+
+	assert.Error(t, LayerSchemaFromStruct(layer, struct {
+		C complex128 `ogr:"c"`
+	}{}))
+	assert.Error(t, LayerSchemaFromStruct(layer, struct {
+		ID string `ogr:",fid"`
+	}{}))
+
+	feature := layer.Definition().Create()
+	defer feature.Destroy()
+	var p testParcel
+	assert.Error(t, feature.Scan(p))
+	assert.Error(t, feature.Scan(&p))
+}
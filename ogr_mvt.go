@@ -0,0 +1,554 @@
+package gdal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Mapbox Vector Tile encoding                                     */
+/* -------------------------------------------------------------------- */
+
+// Bounds of the Web Mercator tile matrix at zoom level 0
+const (
+	webMercatorMinX = -20037508.342789244
+	webMercatorMaxY = 20037508.342789244
+	webMercatorSize = 2 * 20037508.342789244
+)
+
+// MVTLayer selects a layer to encode in a vector tile
+type MVTLayer struct {
+	Layer Layer
+	// Name of the layer in the tile; the layer name if empty
+	Name string
+	// Fields encoded as feature attributes; all the fields if nil
+	Fields []string
+}
+
+// Options for EncodeMVT.  The zero value encodes tiles of the Web Mercator
+// tile matrix with an extent of 4096 and a buffer of 80.
+type MVTOptions struct {
+	// Width of a tile in integer tile coordinates
+	Extent int
+	// Width of the margin around the tile within which geometries are kept,
+	// in integer tile coordinates; negative for no margin
+	Buffer int
+	// Top left corner and width of the tile matrix at zoom level 0, in the
+	// tiling spatial reference.  The Web Mercator tile matrix is used when
+	// TileMatrixSize is 0.
+	TileMatrixMinX, TileMatrixMaxY, TileMatrixSize float64
+}
+
+// Encode the features of layers that fall in tile z/x/y as a Mapbox Vector
+// Tile, version 2.  Geometries are transformed from the spatial reference
+// of their layer to srs, the spatial reference of the tile matrix, clipped
+// to the buffered tile and quantized; when either spatial reference is
+// null they are assumed to be in tile matrix coordinates already.  Layers
+// are read with a spatial filter on the tile, which replaces their own for
+// the duration of the call, and their reading is reset.  A layer must
+// therefore not be used by anything else during the call: a server that
+// renders tiles concurrently must open a dataset, and so a layer handle,
+// per request.  Features that are collections of geometries of several
+// dimensions are encoded as one feature per dimension, with the same
+// identifier and attributes.  Clipping requires GEOS.
+func EncodeMVT(layers []MVTLayer, z, x, y int, srs SpatialReference, options *MVTOptions) ([]byte, error) {
+	var opts MVTOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.Extent <= 0 {
+		opts.Extent = 4096
+	}
+	if opts.Buffer == 0 {
+		opts.Buffer = 80
+	} else if opts.Buffer < 0 {
+		opts.Buffer = 0
+	}
+	if opts.TileMatrixSize == 0 {
+		opts.TileMatrixMinX = webMercatorMinX
+		opts.TileMatrixMaxY = webMercatorMaxY
+		opts.TileMatrixSize = webMercatorSize
+	}
+	if z < 0 || x < 0 || y < 0 || x >= 1<<uint(z) || y >= 1<<uint(z) {
+		return nil, fmt.Errorf("invalid tile %d/%d/%d", z, x, y)
+	}
+
+	size := opts.TileMatrixSize / float64(int(1)<<uint(z))
+	tile := mvtTile{
+		extent: opts.Extent,
+		buffer: opts.Buffer,
+		minX:   opts.TileMatrixMinX + float64(x)*size,
+		maxY:   opts.TileMatrixMaxY - float64(y)*size,
+		size:   size,
+	}
+
+	var out mvtBuffer
+	for _, l := range layers {
+		encoded, err := tile.layer(l, srs)
+		if err != nil {
+			return nil, err
+		}
+		if encoded != nil {
+			out.bytes(3, encoded)
+		}
+	}
+	return out, nil
+}
+
+type mvtTile struct {
+	extent, buffer   int
+	minX, maxY, size float64
+}
+
+// Return the buffered tile as a polygon in tile matrix coordinates
+func (tile mvtTile) clipPolygon(srs SpatialReference) (Geometry, error) {
+	margin := float64(tile.buffer) / float64(tile.extent) * tile.size
+	minX, maxX := tile.minX-margin, tile.minX+tile.size+margin
+	minY, maxY := tile.maxY-tile.size-margin, tile.maxY+margin
+	return CreateFromWKT(fmt.Sprintf(
+		"POLYGON ((%.17g %.17g,%.17g %.17g,%.17g %.17g,%.17g %.17g,%.17g %.17g))",
+		minX, minY, maxX, minY, maxX, maxY, minX, maxY, minX, minY,
+	), srs)
+}
+
+// Encode a layer, returning nil if no features fall in the tile
+func (tile mvtTile) layer(l MVTLayer, srs SpatialReference) ([]byte, error) {
+	clip, err := tile.clipPolygon(srs)
+	if err != nil {
+		return nil, err
+	}
+	defer clip.Destroy()
+
+	// transform features to the tile matrix, and the tile to the layer for
+	// filtering
+	var toTile CoordinateTransform
+	filter := clip.Clone()
+	defer filter.Destroy()
+	layerSRS := l.Layer.SpatialReference()
+	if !srs.IsNull() && !layerSRS.IsNull() && !layerSRS.IsSame(srs) {
+		toTile = CreateCoordinateTransform(layerSRS, srs)
+		if toTile.IsNull() {
+			return nil, fmt.Errorf("layer %s: %v", l.Layer.Name(), toTile.Err())
+		}
+		defer toTile.Destroy()
+		filter.Segmentize(tile.size / 16)
+		if err := filter.TransformTo(layerSRS); err != nil {
+			return nil, fmt.Errorf("layer %s: %v", l.Layer.Name(), err)
+		}
+	}
+
+	previous := l.Layer.SpatialFilter()
+	if !previous.IsNull() {
+		previous = previous.Clone()
+		defer previous.Destroy()
+	}
+	l.Layer.SetSpatialFilter(filter)
+	defer l.Layer.SetSpatialFilter(previous)
+
+	definition := l.Layer.Definition()
+	var fields []int
+	if l.Fields == nil {
+		for i := 0; i < definition.FieldCount(); i++ {
+			fields = append(fields, i)
+		}
+	} else {
+		for _, name := range l.Fields {
+			index := definition.FieldIndex(name)
+			if index < 0 {
+				return nil, fmt.Errorf("layer %s: no field %q", l.Layer.Name(), name)
+			}
+			fields = append(fields, index)
+		}
+	}
+
+	name := l.Name
+	if name == "" {
+		name = l.Layer.Name()
+	}
+	enc := mvtLayerEncoder{
+		tile:   tile,
+		keys:   make(map[string]uint32),
+		values: make(map[mvtValue]uint32),
+	}
+	enc.buf.uint(15, 2)
+	enc.buf.string(1, name)
+
+	err = l.Layer.ForEachFeature(func(feature Feature) error {
+		geom := feature.Geometry()
+		if geom.IsNull() || geom.IsEmpty() {
+			return nil
+		}
+		geom = geom.Clone()
+		defer func() { geom.Destroy() }()
+		if geom.HasCurveGeometry(false) {
+			linear := geom.GetLinearGeometry(0, nil)
+			geom.Destroy()
+			geom = linear
+		}
+		if !toTile.IsNull() {
+			if err := geom.Transform(toTile); err != nil {
+				// features that cannot be projected do not show
+				return nil
+			}
+		}
+		if geom.Dimension() > 0 && !clip.Envelope().Contains(geom.Envelope()) {
+			clipped := geom.Intersection(clip)
+			if clipped.IsNull() {
+				return fmt.Errorf("layer %s: clipping failed: %v", l.Layer.Name(), CPLGetErr())
+			}
+			geom.Destroy()
+			geom = clipped
+		}
+		// a collection can mix points, line strings and polygons, while
+		// an MVT feature has a single type
+		for dim := 0; dim <= 2; dim++ {
+			enc.feature(feature, geom, dim, fields)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if enc.count == 0 {
+		return nil, nil
+	}
+
+	for _, key := range enc.keyList {
+		enc.buf.string(3, key)
+	}
+	for _, value := range enc.valueList {
+		enc.buf.bytes(4, value.encode())
+	}
+	enc.buf.uint(5, uint64(tile.extent))
+	return enc.buf, nil
+}
+
+type mvtLayerEncoder struct {
+	tile      mvtTile
+	buf       mvtBuffer
+	count     int
+	keys      map[string]uint32
+	keyList   []string
+	values    map[mvtValue]uint32
+	valueList []mvtValue
+
+	// reused between features
+	coords   []float64
+	commands []uint32
+	cursorX  int32
+	cursorY  int32
+}
+
+// MVT geometry types and commands
+const (
+	mvtPoint      = 1
+	mvtLineString = 2
+	mvtPolygon    = 3
+
+	mvtMoveTo    = 1
+	mvtLineTo    = 2
+	mvtClosePath = 7
+)
+
+// Encode the parts of geom of dimension dim as a feature, if any
+func (enc *mvtLayerEncoder) feature(feature Feature, geom Geometry, dim int, fields []int) {
+	enc.commands = enc.commands[:0]
+	enc.cursorX, enc.cursorY = 0, 0
+
+	var geomType uint64
+	switch dim {
+	case 0:
+		geomType = mvtPoint
+		enc.points(geom)
+	case 1:
+		geomType = mvtLineString
+		enc.lineStrings(geom)
+	default:
+		geomType = mvtPolygon
+		enc.polygons(geom)
+	}
+	if len(enc.commands) == 0 {
+		return
+	}
+
+	var tags []uint32
+	for _, index := range fields {
+		value, ok := mvtFieldValue(feature, index)
+		if !ok {
+			continue
+		}
+		tags = append(tags, enc.key(feature.FieldDefinition(index).Name()), enc.value(value))
+	}
+
+	var f mvtBuffer
+	if fid := feature.FID(); fid >= 0 {
+		f.uint(1, uint64(fid))
+	}
+	f.packed(2, tags)
+	f.uint(3, geomType)
+	f.packed(4, enc.commands)
+	enc.buf.bytes(2, f)
+	enc.count++
+}
+
+func (enc *mvtLayerEncoder) key(name string) uint32 {
+	index, ok := enc.keys[name]
+	if !ok {
+		index = uint32(len(enc.keyList))
+		enc.keys[name] = index
+		enc.keyList = append(enc.keyList, name)
+	}
+	return index
+}
+
+func (enc *mvtLayerEncoder) value(value mvtValue) uint32 {
+	index, ok := enc.values[value]
+	if !ok {
+		index = uint32(len(enc.valueList))
+		enc.values[value] = index
+		enc.valueList = append(enc.valueList, value)
+	}
+	return index
+}
+
+// Return the quantized vertices of a point, line string or ring,
+// interleaved, without consecutive duplicates
+func (enc *mvtLayerEncoder) vertices(geom Geometry) []int32 {
+	enc.coords = geom.PointsInto(enc.coords[:0], LayoutXY)
+	tile := enc.tile
+	scale := float64(tile.extent) / tile.size
+	vertices := make([]int32, 0, len(enc.coords))
+	for i := 0; i < len(enc.coords); i += 2 {
+		px := int32(math.Round((enc.coords[i] - tile.minX) * scale))
+		py := int32(math.Round((tile.maxY - enc.coords[i+1]) * scale))
+		n := len(vertices)
+		if n > 0 && vertices[n-2] == px && vertices[n-1] == py {
+			continue
+		}
+		vertices = append(vertices, px, py)
+	}
+	return vertices
+}
+
+func (enc *mvtLayerEncoder) command(id, count int) {
+	enc.commands = append(enc.commands, uint32(id&0x7)|uint32(count)<<3)
+}
+
+// Append vertices as parameters, relative to the cursor
+func (enc *mvtLayerEncoder) parameters(vertices []int32) {
+	for i := 0; i < len(vertices); i += 2 {
+		dx, dy := vertices[i]-enc.cursorX, vertices[i+1]-enc.cursorY
+		enc.commands = append(enc.commands, zigzag(dx), zigzag(dy))
+		enc.cursorX, enc.cursorY = vertices[i], vertices[i+1]
+	}
+}
+
+func zigzag(v int32) uint32 {
+	return uint32((v << 1) ^ (v >> 31))
+}
+
+func (enc *mvtLayerEncoder) points(geom Geometry) {
+	lo, hi := int32(-enc.tile.buffer), int32(enc.tile.extent+enc.tile.buffer)
+	var vertices []int32
+	for _, part := range geometryParts(geom, 0) {
+		v := enc.vertices(part)
+		if len(v) == 2 && v[0] >= lo && v[0] <= hi && v[1] >= lo && v[1] <= hi {
+			vertices = append(vertices, v...)
+		}
+	}
+	if len(vertices) > 0 {
+		enc.command(mvtMoveTo, len(vertices)/2)
+		enc.parameters(vertices)
+	}
+}
+
+func (enc *mvtLayerEncoder) lineStrings(geom Geometry) {
+	for _, part := range geometryParts(geom, 1) {
+		vertices := enc.vertices(part)
+		if len(vertices) < 4 {
+			continue
+		}
+		enc.command(mvtMoveTo, 1)
+		enc.parameters(vertices[:2])
+		enc.command(mvtLineTo, len(vertices)/2-1)
+		enc.parameters(vertices[2:])
+	}
+}
+
+func (enc *mvtLayerEncoder) polygons(geom Geometry) {
+	for _, polygon := range geometryParts(geom, 2) {
+		for i := 0; i < polygon.GeometryCount(); i++ {
+			vertices := enc.vertices(polygon.Geometry(i))
+			if n := len(vertices); n >= 4 && vertices[0] == vertices[n-2] && vertices[1] == vertices[n-1] {
+				vertices = vertices[:n-2]
+			}
+			area := ringArea(vertices)
+			if len(vertices) < 6 || area == 0 {
+				if i == 0 {
+					// without its exterior ring, drop the polygon
+					break
+				}
+				continue
+			}
+			// exterior rings have a positive area in tile coordinates, that
+			// is they turn clockwise with the y axis pointing down, and
+			// interior rings a negative one
+			if (i == 0) != (area > 0) {
+				reverseVertices(vertices)
+			}
+			enc.command(mvtMoveTo, 1)
+			enc.parameters(vertices[:2])
+			enc.command(mvtLineTo, len(vertices)/2-1)
+			enc.parameters(vertices[2:])
+			enc.command(mvtClosePath, 1)
+		}
+	}
+}
+
+// Return twice the signed area of a ring of interleaved vertices
+func ringArea(vertices []int32) int64 {
+	var area int64
+	n := len(vertices)
+	for i := 0; i < n; i += 2 {
+		j := (i + 2) % n
+		area += int64(vertices[i])*int64(vertices[j+1]) - int64(vertices[j])*int64(vertices[i+1])
+	}
+	return area
+}
+
+func reverseVertices(vertices []int32) {
+	for i, j := 0, len(vertices)-2; i < j; i, j = i+2, j-2 {
+		vertices[i], vertices[j] = vertices[j], vertices[i]
+		vertices[i+1], vertices[j+1] = vertices[j+1], vertices[i+1]
+	}
+}
+
+// Return the points, line strings or polygons, by dimension, that make up
+// a geometry
+func geometryParts(geom Geometry, dim int) []Geometry {
+	switch geom.Type().Flatten() {
+	case GT_Point:
+		if dim == 0 && !geom.IsEmpty() {
+			return []Geometry{geom}
+		}
+		return nil
+	case GT_LineString, GT_LinearRing:
+		if dim == 1 {
+			return []Geometry{geom}
+		}
+		return nil
+	case GT_Polygon:
+		if dim == 2 {
+			return []Geometry{geom}
+		}
+		return nil
+	}
+	var parts []Geometry
+	for i := 0; i < geom.GeometryCount(); i++ {
+		parts = append(parts, geometryParts(geom.Geometry(i), dim)...)
+	}
+	return parts
+}
+
+// mvtValue is an attribute value, of one of the protobuf Value kinds
+type mvtValue struct {
+	kind int
+	s    string
+	d    float64
+	i    int64
+	b    bool
+}
+
+// Value kinds, numbered as their protobuf fields
+const (
+	mvtString = 1
+	mvtDouble = 3
+	mvtInt    = 4
+	mvtBool   = 7
+)
+
+func (v mvtValue) encode() []byte {
+	var b mvtBuffer
+	switch v.kind {
+	case mvtString:
+		b.string(mvtString, v.s)
+	case mvtDouble:
+		b.double(mvtDouble, v.d)
+	case mvtInt:
+		b.uint(mvtInt, uint64(v.i))
+	case mvtBool:
+		if v.b {
+			b.uint(mvtBool, 1)
+		} else {
+			b.uint(mvtBool, 0)
+		}
+	}
+	return b
+}
+
+// Return the value of a field, and false if it is unset or null
+func mvtFieldValue(feature Feature, index int) (mvtValue, bool) {
+	if !feature.IsFieldSetAndNotNull(index) {
+		return mvtValue{}, false
+	}
+	fieldDefn := feature.FieldDefinition(index)
+	switch fieldDefn.Type() {
+	case FT_Integer:
+		if fieldDefn.SubType() == FST_Boolean {
+			return mvtValue{kind: mvtBool, b: feature.FieldAsBool(index)}, true
+		}
+		return mvtValue{kind: mvtInt, i: int64(feature.FieldAsInteger(index))}, true
+	case FT_Integer64:
+		return mvtValue{kind: mvtInt, i: feature.FieldAsInteger64(index)}, true
+	case FT_Real:
+		return mvtValue{kind: mvtDouble, d: feature.FieldAsFloat64(index)}, true
+	}
+	return mvtValue{kind: mvtString, s: feature.FieldAsString(index)}, true
+}
+
+// mvtBuffer is a protobuf message being encoded
+type mvtBuffer []byte
+
+func (b *mvtBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		*b = append(*b, byte(v)|0x80)
+		v >>= 7
+	}
+	*b = append(*b, byte(v))
+}
+
+func (b *mvtBuffer) uint(field int, v uint64) {
+	b.varint(uint64(field)<<3 | 0)
+	b.varint(v)
+}
+
+func (b *mvtBuffer) double(field int, v float64) {
+	b.varint(uint64(field)<<3 | 1)
+	var bits [8]byte
+	binary.LittleEndian.PutUint64(bits[:], math.Float64bits(v))
+	*b = append(*b, bits[:]...)
+}
+
+func (b *mvtBuffer) bytes(field int, data []byte) {
+	b.varint(uint64(field)<<3 | 2)
+	b.varint(uint64(len(data)))
+	*b = append(*b, data...)
+}
+
+func (b *mvtBuffer) string(field int, s string) {
+	b.varint(uint64(field)<<3 | 2)
+	b.varint(uint64(len(s)))
+	*b = append(*b, s...)
+}
+
+func (b *mvtBuffer) packed(field int, values []uint32) {
+	if len(values) == 0 {
+		return
+	}
+	var packed mvtBuffer
+	for _, v := range values {
+		packed.varint(uint64(v))
+	}
+	b.bytes(field, packed)
+}
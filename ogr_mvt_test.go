@@ -0,0 +1,202 @@
+package gdal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pbField is a decoded protobuf field: a varint, or bytes for length
+// delimited fields
+type pbField struct {
+	num   int
+	value uint64
+	bytes []byte
+}
+
+func decodePB(t *testing.T, data []byte) []pbField {
+	var fields []pbField
+	varint := func() uint64 {
+		var v uint64
+		for shift := uint(0); ; shift += 7 {
+			if len(data) == 0 {
+				t.Fatal("truncated protobuf")
+			}
+			b := data[0]
+			data = data[1:]
+			v |= uint64(b&0x7f) << shift
+			if b < 0x80 {
+				return v
+			}
+		}
+	}
+	for len(data) > 0 {
+		key := varint()
+		f := pbField{num: int(key >> 3)}
+		switch key & 7 {
+		case 0:
+			f.value = varint()
+		case 1:
+			f.bytes = data[:8]
+			data = data[8:]
+		case 2:
+			n := varint()
+			f.bytes = data[:n]
+			data = data[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func decodePacked(t *testing.T, data []byte) []uint32 {
+	var values []uint32
+	var v uint32
+	var shift uint
+	for _, b := range data {
+		v |= uint32(b&0x7f) << shift
+		shift += 7
+		if b < 0x80 {
+			values = append(values, v)
+			v, shift = 0, 0
+		}
+	}
+	if shift != 0 {
+		t.Fatal("truncated packed field")
+	}
+	return values
+}
+
+func TestEncodeMVT(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("places", SpatialReference{}, GT_Unknown, nil)
+	name := CreateFieldDefinition("name", FT_String)
+	assert.NoError(t, layer.CreateField(name, true))
+	name.Destroy()
+	rank := CreateFieldDefinition("rank", FT_Integer)
+	assert.NoError(t, layer.CreateField(rank, true))
+	rank.Destroy()
+
+	for _, wkt := range []string{
+		"POINT (0 0)",
+		// counter-clockwise, both in map and in tile coordinates
+		"POLYGON ((0 0,0 -10000000,10000000 -10000000,10000000 0,0 0))",
+		"POINT (30000000 0)",
+	} {
+		feature := layer.Definition().Create()
+		feature.SetFieldString(0, "a")
+		feature.SetFieldInteger(1, 3)
+		geom, _ := CreateFromWKT(wkt, SpatialReference{})
+		feature.SetGeometryDirectly(geom)
+		assert.NoError(t, layer.Create(feature))
+		feature.Destroy()
+	}
+
+	data, err := EncodeMVT([]MVTLayer{{Layer: layer, Name: "poi"}}, 0, 0, 0, SpatialReference{}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tile := decodePB(t, data)
+	if !assert.Len(t, tile, 1) {
+		return
+	}
+	assert.Equal(t, 3, tile[0].num)
+
+	var features [][]pbField
+	var keys []string
+	var values int
+	for _, f := range decodePB(t, tile[0].bytes) {
+		switch f.num {
+		case 15:
+			assert.Equal(t, uint64(2), f.value)
+		case 1:
+			assert.Equal(t, "poi", string(f.bytes))
+		case 2:
+			features = append(features, decodePB(t, f.bytes))
+		case 3:
+			keys = append(keys, string(f.bytes))
+		case 4:
+			values++
+		case 5:
+			assert.Equal(t, uint64(4096), f.value)
+		}
+	}
+	assert.Equal(t, []string{"name", "rank"}, keys)
+	assert.Equal(t, 2, values)
+	// the point outside the world is left out
+	if !assert.Len(t, features, 2) {
+		return
+	}
+
+	geometry := func(feature []pbField) (uint64, []uint32) {
+		var geomType uint64
+		var commands []uint32
+		for _, f := range feature {
+			switch f.num {
+			case 2:
+				assert.Equal(t, []uint32{0, 0, 1, 1}, decodePacked(t, f.bytes))
+			case 3:
+				geomType = f.value
+			case 4:
+				commands = decodePacked(t, f.bytes)
+			}
+		}
+		return geomType, commands
+	}
+
+	geomType, commands := geometry(features[0])
+	assert.Equal(t, uint64(1), geomType)
+	assert.Equal(t, []uint32{9, 4096, 4096}, commands)
+
+	// the exterior ring spans 2048,2048 to 3070,3070 in tile coordinates,
+	// and is reversed to turn clockwise with y pointing down
+	geomType, commands = geometry(features[1])
+	assert.Equal(t, uint64(3), geomType)
+	assert.Equal(t, []uint32{9, 6140, 4096, 26, 0, 2044, 2043, 0, 0, 2043, 15}, commands)
+}
+
+func TestEncodeMVTMixedCollection(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("mixed", SpatialReference{}, GT_Unknown, nil)
+	feature := layer.Definition().Create()
+	geom, _ := CreateFromWKT("GEOMETRYCOLLECTION (POINT (0 0),LINESTRING (0 0,10000000 0))", SpatialReference{})
+	feature.SetGeometryDirectly(geom)
+	assert.NoError(t, layer.Create(feature))
+	fid := feature.FID()
+	feature.Destroy()
+
+	data, err := EncodeMVT([]MVTLayer{{Layer: layer}}, 0, 0, 0, SpatialReference{}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	tile := decodePB(t, data)
+	if !assert.Len(t, tile, 1) {
+		return
+	}
+
+	// the point and the line string are encoded as two features
+	var types []uint64
+	var commands [][]uint32
+	for _, f := range decodePB(t, tile[0].bytes) {
+		if f.num != 2 {
+			continue
+		}
+		for _, ff := range decodePB(t, f.bytes) {
+			switch ff.num {
+			case 1:
+				assert.Equal(t, uint64(fid), ff.value)
+			case 3:
+				types = append(types, ff.value)
+			case 4:
+				commands = append(commands, decodePacked(t, ff.bytes))
+			}
+		}
+	}
+	assert.Equal(t, []uint64{1, 2}, types)
+	assert.Equal(t, [][]uint32{{9, 4096, 4096}, {9, 4096, 4096, 10, 2044, 0}}, commands)
+}
@@ -0,0 +1,493 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_ogr_wkb.h"
+*/
+import "C"
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      Native Go geometries                                            */
+/* -------------------------------------------------------------------- */
+
+// The native geometry types below hold their coordinates in pure Go
+// memory, so they can be processed without a cgo call per vertex.
+// Conversions to and from Geometry go through a single ISO WKB buffer.
+//
+// Coordinates are stored flat, with CoordLayout.Stride() values per vertex:
+// x, y, then z and/or m when the layout has them.
+
+// CoordLayout describes the ordinates stored for each vertex
+type CoordLayout int
+
+const (
+	LayoutXY = CoordLayout(iota)
+	LayoutXYZ
+	LayoutXYM
+	LayoutXYZM
+)
+
+// Return the number of ordinates per vertex
+func (layout CoordLayout) Stride() int {
+	switch layout {
+	case LayoutXYZ, LayoutXYM:
+		return 3
+	case LayoutXYZM:
+		return 4
+	}
+	return 2
+}
+
+// Test if the layout has a z ordinate
+func (layout CoordLayout) HasZ() bool {
+	return layout == LayoutXYZ || layout == LayoutXYZM
+}
+
+// Test if the layout has an m ordinate
+func (layout CoordLayout) HasM() bool {
+	return layout == LayoutXYM || layout == LayoutXYZM
+}
+
+// NativeGeometry is implemented by the native geometry types
+type NativeGeometry interface {
+	// Return the OGR geometry type, including the Z/M variant
+	GeometryType() GeometryType
+}
+
+// NativePoint is a single vertex.  An empty point has no coordinates.
+type NativePoint struct {
+	Layout CoordLayout
+	Coords []float64
+}
+
+// NativeLineString is a sequence of vertices
+type NativeLineString struct {
+	Layout CoordLayout
+	Coords []float64
+}
+
+// NativePolygon is an exterior ring followed by any interior rings, each a
+// closed sequence of vertices
+type NativePolygon struct {
+	Layout CoordLayout
+	Rings  [][]float64
+}
+
+type NativeMultiPoint struct {
+	Layout CoordLayout
+	Points []NativePoint
+}
+
+type NativeMultiLineString struct {
+	Layout      CoordLayout
+	LineStrings []NativeLineString
+}
+
+type NativeMultiPolygon struct {
+	Layout   CoordLayout
+	Polygons []NativePolygon
+}
+
+type NativeGeometryCollection struct {
+	Layout     CoordLayout
+	Geometries []NativeGeometry
+}
+
+func (g NativePoint) GeometryType() GeometryType {
+	return layoutGeometryType(GT_Point, g.Layout)
+}
+
+func (g NativeLineString) GeometryType() GeometryType {
+	return layoutGeometryType(GT_LineString, g.Layout)
+}
+
+func (g NativePolygon) GeometryType() GeometryType {
+	return layoutGeometryType(GT_Polygon, g.Layout)
+}
+
+func (g NativeMultiPoint) GeometryType() GeometryType {
+	return layoutGeometryType(GT_MultiPoint, g.Layout)
+}
+
+func (g NativeMultiLineString) GeometryType() GeometryType {
+	return layoutGeometryType(GT_MultiLineString, g.Layout)
+}
+
+func (g NativeMultiPolygon) GeometryType() GeometryType {
+	return layoutGeometryType(GT_MultiPolygon, g.Layout)
+}
+
+func (g NativeGeometryCollection) GeometryType() GeometryType {
+	return layoutGeometryType(GT_GeometryCollection, g.Layout)
+}
+
+// Return the OGR variant of a 2D geometry type for a layout
+func layoutGeometryType(base GeometryType, layout CoordLayout) GeometryType {
+	switch layout {
+	case LayoutXYZ:
+		return base | 0x80000000
+	case LayoutXYM:
+		return base + 2000
+	case LayoutXYZM:
+		return base + 3000
+	}
+	return base
+}
+
+// Convert the geometry to a native Go geometry.  Only points, line
+// strings, polygons, their multi variants and geometry collections are
+// supported.
+func (geom Geometry) ToNative() (NativeGeometry, error) {
+	if geom.cval == nil {
+		return nil, ErrInvalidHandle
+	}
+	wkb := make([]byte, geom.WKBSize())
+	cErr := C.go_ExportToIsoWkb(
+		geom.cval, C.OGRwkbByteOrder(C.wkbNDR), (*C.uchar)(unsafe.Pointer(&wkb[0])),
+	)
+	if err := (OGRErrContainer{ErrVal: cErr}).Err(); err != nil {
+		return nil, err
+	}
+	r := wkbReader{data: wkb}
+	return r.geometry()
+}
+
+// Create a geometry from a native Go geometry
+func CreateFromNative(g NativeGeometry, srs SpatialReference) (Geometry, error) {
+	var w wkbWriter
+	if err := w.geometry(g); err != nil {
+		return Geometry{}, err
+	}
+	return CreateFromWKB(w.buf, srs, len(w.buf))
+}
+
+/* -------------------------------------------------------------------- */
+/*      WKB encoding                                                    */
+/* -------------------------------------------------------------------- */
+
+type wkbWriter struct {
+	buf []byte
+}
+
+func (w *wkbWriter) header(base GeometryType, layout CoordLayout) {
+	code := uint32(base)
+	switch layout {
+	case LayoutXYZ:
+		code += 1000
+	case LayoutXYM:
+		code += 2000
+	case LayoutXYZM:
+		code += 3000
+	}
+	w.buf = append(w.buf, 1)
+	w.uint32(code)
+}
+
+func (w *wkbWriter) uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *wkbWriter) coords(layout CoordLayout, coords []float64, counted bool) error {
+	stride := layout.Stride()
+	if len(coords)%stride != 0 {
+		return fmt.Errorf("%d coordinates do not fit a stride of %d", len(coords), stride)
+	}
+	if counted {
+		w.uint32(uint32(len(coords) / stride))
+	}
+	var b [8]byte
+	for _, v := range coords {
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		w.buf = append(w.buf, b[:]...)
+	}
+	return nil
+}
+
+func (w *wkbWriter) geometry(g NativeGeometry) error {
+	switch g := g.(type) {
+	case NativePoint:
+		w.header(GT_Point, g.Layout)
+		if len(g.Coords) == 0 {
+			empty := make([]float64, g.Layout.Stride())
+			for i := range empty {
+				empty[i] = math.NaN()
+			}
+			return w.coords(g.Layout, empty, false)
+		}
+		if len(g.Coords) != g.Layout.Stride() {
+			return fmt.Errorf("point has %d coordinates, expected %d", len(g.Coords), g.Layout.Stride())
+		}
+		return w.coords(g.Layout, g.Coords, false)
+	case NativeLineString:
+		w.header(GT_LineString, g.Layout)
+		return w.coords(g.Layout, g.Coords, true)
+	case NativePolygon:
+		w.header(GT_Polygon, g.Layout)
+		w.uint32(uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			if err := w.coords(g.Layout, ring, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	case NativeMultiPoint:
+		w.header(GT_MultiPoint, g.Layout)
+		w.uint32(uint32(len(g.Points)))
+		for _, part := range g.Points {
+			if err := w.part(g.Layout, part.Layout, part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case NativeMultiLineString:
+		w.header(GT_MultiLineString, g.Layout)
+		w.uint32(uint32(len(g.LineStrings)))
+		for _, part := range g.LineStrings {
+			if err := w.part(g.Layout, part.Layout, part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case NativeMultiPolygon:
+		w.header(GT_MultiPolygon, g.Layout)
+		w.uint32(uint32(len(g.Polygons)))
+		for _, part := range g.Polygons {
+			if err := w.part(g.Layout, part.Layout, part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case NativeGeometryCollection:
+		w.header(GT_GeometryCollection, g.Layout)
+		w.uint32(uint32(len(g.Geometries)))
+		for _, part := range g.Geometries {
+			if err := w.geometry(part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *NativePoint:
+		return w.geometry(*g)
+	case *NativeLineString:
+		return w.geometry(*g)
+	case *NativePolygon:
+		return w.geometry(*g)
+	case *NativeMultiPoint:
+		return w.geometry(*g)
+	case *NativeMultiLineString:
+		return w.geometry(*g)
+	case *NativeMultiPolygon:
+		return w.geometry(*g)
+	case *NativeGeometryCollection:
+		return w.geometry(*g)
+	}
+	return fmt.Errorf("%s: %T", ErrUnsupportedGeometryType, g)
+}
+
+// Write a part of a multi geometry, which must share its layout
+func (w *wkbWriter) part(layout, partLayout CoordLayout, part NativeGeometry) error {
+	if partLayout != layout {
+		return fmt.Errorf("part layout %d does not match the multi geometry layout %d", partLayout, layout)
+	}
+	return w.geometry(part)
+}
+
+/* -------------------------------------------------------------------- */
+/*      WKB decoding                                                    */
+/* -------------------------------------------------------------------- */
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+var errTruncatedWKB = fmt.Errorf("%s: truncated WKB", ErrCorruptData)
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.data)-r.pos < 4 {
+		return 0, errTruncatedWKB
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// Read count vertices, or a count followed by as many vertices when count
+// is negative
+func (r *wkbReader) coords(layout CoordLayout, count int) ([]float64, error) {
+	if count < 0 {
+		n, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		count = int(n)
+	}
+	stride := layout.Stride()
+	if count > (len(r.data)-r.pos)/(8*stride) {
+		return nil, errTruncatedWKB
+	}
+	coords := make([]float64, count*stride)
+	for i := range coords {
+		coords[i] = math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+		r.pos += 8
+	}
+	return coords, nil
+}
+
+// Read a count of parts, checking it against the remaining data
+func (r *wkbReader) count() (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	// every part takes at least a byte order and a type, or a count
+	if int(n) > (len(r.data)-r.pos)/4 {
+		return 0, errTruncatedWKB
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) geometry() (NativeGeometry, error) {
+	if r.pos >= len(r.data) {
+		return nil, errTruncatedWKB
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("%s: invalid WKB byte order", ErrCorruptData)
+	}
+	r.pos++
+	code, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	// accept the EWKB flags along with the ISO type codes
+	hasZ := code&0x80000000 != 0
+	hasM := code&0x40000000 != 0
+	if code&0x20000000 != 0 {
+		if _, err := r.uint32(); err != nil {
+			return nil, err
+		}
+	}
+	code &= 0x0fffffff
+	switch code / 1000 {
+	case 1:
+		hasZ = true
+	case 2:
+		hasM = true
+	case 3:
+		hasZ, hasM = true, true
+	}
+	layout := LayoutXY
+	switch {
+	case hasZ && hasM:
+		layout = LayoutXYZM
+	case hasZ:
+		layout = LayoutXYZ
+	case hasM:
+		layout = LayoutXYM
+	}
+
+	switch GeometryType(code % 1000) {
+	case GT_Point:
+		coords, err := r.coords(layout, 1)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(coords[0]) && math.IsNaN(coords[1]) {
+			coords = nil
+		}
+		return NativePoint{layout, coords}, nil
+	case GT_LineString:
+		coords, err := r.coords(layout, -1)
+		if err != nil {
+			return nil, err
+		}
+		return NativeLineString{layout, coords}, nil
+	case GT_Polygon:
+		n, err := r.count()
+		if err != nil {
+			return nil, err
+		}
+		rings := make([][]float64, n)
+		for i := range rings {
+			if rings[i], err = r.coords(layout, -1); err != nil {
+				return nil, err
+			}
+		}
+		return NativePolygon{layout, rings}, nil
+	case GT_MultiPoint:
+		parts, err := r.parts()
+		if err != nil {
+			return nil, err
+		}
+		points := make([]NativePoint, len(parts))
+		for i, part := range parts {
+			var ok bool
+			if points[i], ok = part.(NativePoint); !ok {
+				return nil, fmt.Errorf("%s: multipoint part is a %T", ErrCorruptData, part)
+			}
+		}
+		return NativeMultiPoint{layout, points}, nil
+	case GT_MultiLineString:
+		parts, err := r.parts()
+		if err != nil {
+			return nil, err
+		}
+		lines := make([]NativeLineString, len(parts))
+		for i, part := range parts {
+			var ok bool
+			if lines[i], ok = part.(NativeLineString); !ok {
+				return nil, fmt.Errorf("%s: multilinestring part is a %T", ErrCorruptData, part)
+			}
+		}
+		return NativeMultiLineString{layout, lines}, nil
+	case GT_MultiPolygon:
+		parts, err := r.parts()
+		if err != nil {
+			return nil, err
+		}
+		polygons := make([]NativePolygon, len(parts))
+		for i, part := range parts {
+			var ok bool
+			if polygons[i], ok = part.(NativePolygon); !ok {
+				return nil, fmt.Errorf("%s: multipolygon part is a %T", ErrCorruptData, part)
+			}
+		}
+		return NativeMultiPolygon{layout, polygons}, nil
+	case GT_GeometryCollection:
+		parts, err := r.parts()
+		if err != nil {
+			return nil, err
+		}
+		return NativeGeometryCollection{layout, parts}, nil
+	}
+	return nil, fmt.Errorf("%s: WKB type %d", ErrUnsupportedGeometryType, code)
+}
+
+func (r *wkbReader) parts() ([]NativeGeometry, error) {
+	n, err := r.count()
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]NativeGeometry, n)
+	for i := range parts {
+		if parts[i], err = r.geometry(); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
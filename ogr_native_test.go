@@ -0,0 +1,67 @@
+package gdal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNativeGeometryRoundTrip(t *testing.T) {
+	tests := []string{
+		"POINT (1 2)",
+		"POINT EMPTY",
+		"POINT Z (1 2 3)",
+		"POINT M (1 2 4)",
+		"POINT ZM (1 2 3 4)",
+		"LINESTRING (0 0,1 1,2 0)",
+		"LINESTRING ZM (0 0 1 2,1 1 3 4)",
+		"POLYGON ((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))",
+		"MULTIPOINT (0 0,1 1)",
+		"MULTILINESTRING Z ((0 0 1,1 1 1),(2 2 2,3 3 3))",
+		"MULTIPOLYGON (((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))",
+		"GEOMETRYCOLLECTION (POINT (1 2),LINESTRING (0 0,1 1))",
+	}
+	for _, wkt := range tests {
+		geom, err := CreateFromWKT(wkt, SpatialReference{})
+		if !assert.NoError(t, err, wkt) {
+			continue
+		}
+		native, err := geom.ToNative()
+		if assert.NoError(t, err, wkt) {
+			assert.Equal(t, geom.Type(), native.GeometryType(), wkt)
+			back, err := CreateFromNative(native, SpatialReference{})
+			if assert.NoError(t, err, wkt) {
+				out, _ := back.ToWKT()
+				expected, _ := geom.ToWKT()
+				assert.Equal(t, expected, out)
+				back.Destroy()
+			}
+		}
+		geom.Destroy()
+	}
+}
+
+func TestNativeGeometryValues(t *testing.T) {
+	geom, _ := CreateFromWKT("POLYGON Z ((0 0 1,4 0 2,4 4 3,0 0 1))", SpatialReference{})
+	defer geom.Destroy()
+	native, err := geom.ToNative()
+	assert.NoError(t, err)
+	assert.Equal(t, NativePolygon{
+		Layout: LayoutXYZ,
+		Rings:  [][]float64{{0, 0, 1, 4, 0, 2, 4, 4, 3, 0, 0, 1}},
+	}, native)
+
+	line, err := CreateFromNative(&NativeLineString{Layout: LayoutXYM, Coords: []float64{0, 0, 5, 3, 4, 6}}, SpatialReference{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, line.Length())
+	line.Destroy()
+
+	_, err = CreateFromNative(NativeLineString{Layout: LayoutXYZ, Coords: []float64{0, 0, 5, 3}}, SpatialReference{})
+	assert.Error(t, err)
+	_, err = CreateFromNative(NativeMultiPoint{Layout: LayoutXY, Points: []NativePoint{{LayoutXYZ, []float64{1, 2, 3}}}}, SpatialReference{})
+	assert.Error(t, err)
+
+	r := wkbReader{data: []byte{1, 2, 0, 0, 0, 10, 0, 0, 0}}
+	_, err = r.geometry()
+	assert.Error(t, err)
+}
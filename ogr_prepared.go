@@ -0,0 +1,62 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_ogr_prepared.h"
+*/
+import "C"
+import "fmt"
+
+/* -------------------------------------------------------------------- */
+/*      Prepared geometry functions                                     */
+/* -------------------------------------------------------------------- */
+
+// PreparedGeometry is a geometry preprocessed for repeated spatial
+// predicates against other geometries.  It does not reference the geometry
+// it was created from, which may be destroyed afterwards.  A prepared
+// geometry must not be used from several goroutines at once.
+type PreparedGeometry struct {
+	cval *C.goPreparedGeometry
+}
+
+// Test if prepared geometries are supported, which requires GEOS
+func HasPreparedGeometrySupport() bool {
+	return C.go_HasPreparedGeometrySupport() != 0
+}
+
+// Create a prepared geometry from a geometry
+func CreatePreparedGeometry(geom Geometry) (PreparedGeometry, error) {
+	prepared := C.go_CreatePreparedGeometry(geom.cval)
+	if prepared == nil {
+		return PreparedGeometry{}, fmt.Errorf("Error: unable to prepare geometry: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return PreparedGeometry{prepared}, nil
+}
+
+// Destroy the prepared geometry
+func (pg PreparedGeometry) Destroy() {
+	C.go_DestroyPreparedGeometry(pg.cval)
+}
+
+// Test if the prepared geometry intersects the other geometry
+func (pg PreparedGeometry) Intersects(other Geometry) bool {
+	val := C.go_PreparedGeometryIntersects(pg.cval, other.cval)
+	return val != 0
+}
+
+// Test if the prepared geometry contains the other geometry
+func (pg PreparedGeometry) Contains(other Geometry) bool {
+	val := C.go_PreparedGeometryContains(pg.cval, other.cval)
+	return val != 0
+}
+
+// Test if the other geometry lies within the interior of the prepared
+// geometry, without touching its boundary.  An error is returned when GEOS
+// cannot compute the boundary, as for geometry collections.
+func (pg PreparedGeometry) ContainsProperly(other Geometry) (bool, error) {
+	val := C.go_PreparedGeometryContainsProperly(pg.cval, other.cval)
+	if val < 0 {
+		return false, fmt.Errorf("Error: unable to compute the boundary of the prepared geometry: %s", C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return val != 0, nil
+}
@@ -0,0 +1,135 @@
+package gdal
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreparedGeometry(t *testing.T) {
+	if !HasPreparedGeometrySupport() {
+		t.Skip("GDAL built without GEOS")
+	}
+	zone, _ := CreateFromWKT("POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))", SpatialReference{})
+	prepared, err := CreatePreparedGeometry(zone)
+	zone.Destroy()
+	if err != nil {
+		t.Fatalf("CreatePreparedGeometry: %v", err)
+	}
+	defer prepared.Destroy()
+
+	tests := []struct {
+		wkt                                    string
+		intersects, contains, containsProperly bool
+	}{
+		{"POINT (5 5)", true, true, true},
+		{"POINT (10 5)", true, false, false},
+		{"POINT (20 5)", false, false, false},
+		{"LINESTRING (0 0, 5 5)", true, true, false},
+		{"LINESTRING (5 5, 15 5)", true, false, false},
+	}
+	for _, test := range tests {
+		geom, _ := CreateFromWKT(test.wkt, SpatialReference{})
+		assert.Equal(t, test.intersects, prepared.Intersects(geom), test.wkt)
+		assert.Equal(t, test.contains, prepared.Contains(geom), test.wkt)
+		containsProperly, err := prepared.ContainsProperly(geom)
+		assert.NoError(t, err)
+		assert.Equal(t, test.containsProperly, containsProperly, test.wkt)
+		geom.Destroy()
+	}
+}
+
+func TestPreparedGeometryContainsProperlyBoundary(t *testing.T) {
+	if !HasPreparedGeometrySupport() {
+		t.Skip("GDAL built without GEOS")
+	}
+	inner, _ := CreateFromWKT("POINT (1 1)", SpatialReference{})
+	defer inner.Destroy()
+
+	// a point has an empty boundary, so it contains itself properly
+	point, _ := CreateFromWKT("POINT (1 1)", SpatialReference{})
+	prepared, err := CreatePreparedGeometry(point)
+	point.Destroy()
+	if err != nil {
+		t.Fatalf("CreatePreparedGeometry: %v", err)
+	}
+	containsProperly, err := prepared.ContainsProperly(inner)
+	assert.NoError(t, err)
+	assert.True(t, containsProperly)
+	prepared.Destroy()
+
+	collection, _ := CreateFromWKT("GEOMETRYCOLLECTION (POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0)), POINT (5 5))", SpatialReference{})
+	prepared, err = CreatePreparedGeometry(collection)
+	collection.Destroy()
+	if err != nil {
+		t.Fatalf("CreatePreparedGeometry: %v", err)
+	}
+	defer prepared.Destroy()
+	_, err = prepared.ContainsProperly(inner)
+	assert.Error(t, err)
+}
+
+// Point in polygon tests of random points against a grid of zones, with
+// and without preparing the zones
+func BenchmarkPointInZone(b *testing.B) {
+	if !HasPreparedGeometrySupport() {
+		b.Skip("GDAL built without GEOS")
+	}
+	const gridSize = 16
+	var zones []Geometry
+	for i := 0; i < gridSize; i++ {
+		for j := 0; j < gridSize; j++ {
+			x, y := float64(i)*10, float64(j)*10
+			// a circle approximated by 64 segments per quadrant
+			center, _ := CreateFromWKT(fmt.Sprintf("POINT (%g %g)", x+5, y+5), SpatialReference{})
+			zones = append(zones, center.Buffer(5, 64))
+			center.Destroy()
+		}
+	}
+	defer func() {
+		for _, zone := range zones {
+			zone.Destroy()
+		}
+	}()
+
+	rng := rand.New(rand.NewSource(1))
+	points := make([]Geometry, 1024)
+	for i := range points {
+		points[i] = Create(GT_Point)
+		points[i].AddPoint2D(rng.Float64()*gridSize*10, rng.Float64()*gridSize*10)
+	}
+	defer func() {
+		for _, point := range points {
+			point.Destroy()
+		}
+	}()
+
+	b.Run("Unprepared", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			point := points[n%len(points)]
+			for _, zone := range zones {
+				zone.Contains(point)
+			}
+		}
+	})
+
+	b.Run("Prepared", func(b *testing.B) {
+		prepared := make([]PreparedGeometry, len(zones))
+		for i, zone := range zones {
+			var err error
+			if prepared[i], err = CreatePreparedGeometry(zone); err != nil {
+				b.Fatalf("CreatePreparedGeometry: %v", err)
+			}
+			defer prepared[i].Destroy()
+		}
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			point := points[n%len(points)]
+			for _, zone := range prepared {
+				zone.Contains(point)
+			}
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package gdal
 
 import (
+	"errors"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,3 +60,645 @@ func TestEnvelope3D(t *testing.T) {
 	assert.Equal(t, env_is.MaxZ(), env1.MaxZ())
 
 }
+
+func TestLayerFeatures(t *testing.T) {
+	ds, err := OpenEx("testdata/test.shp", OFReadOnly|OFVector, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer ds.Close()
+	layer := ds.LayerByIndex(0)
+	expected, _ := layer.FeatureCount(true)
+
+	count := 0
+	it := layer.Features()
+	for it.Next() {
+		assert.False(t, it.Feature().IsNull())
+		count++
+	}
+	it.Close()
+	assert.NoError(t, it.Err())
+	assert.Equal(t, expected, count)
+
+	// a second pass starts over, and stops cleanly on break
+	count = 0
+	err = layer.ForEachFeature(func(feature Feature) error {
+		count++
+		return errStopIteration
+	})
+	assert.Equal(t, errStopIteration, err)
+	assert.Equal(t, 1, count)
+}
+
+var errStopIteration = errors.New("stop")
+
+// addPolygon adds a feature with the given WKT geometry to the layer
+func addPolygon(t *testing.T, layer Layer, wkt string) {
+	geom, err := CreateFromWKT(wkt, SpatialReference{})
+	if err != nil {
+		t.Fatalf("CreateFromWKT: %v", err)
+	}
+	feature := layer.Definition().Create()
+	defer feature.Destroy()
+	feature.SetGeometryDirectly(geom)
+	if err := layer.Create(feature); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+}
+
+func TestLayerAlgebra(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	input := ds.CreateLayer("input", SpatialReference{}, GT_Polygon, nil)
+	addPolygon(t, input, "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))")
+	method := ds.CreateLayer("method", SpatialReference{}, GT_Polygon, nil)
+	addPolygon(t, method, "POLYGON ((1 1, 3 1, 3 3, 1 3, 1 1))")
+
+	area := func(layer Layer) float64 {
+		total := 0.0
+		layer.ForEachFeature(func(feature Feature) error {
+			total += feature.Geometry().Area()
+			return nil
+		})
+		return total
+	}
+
+	progressCalled := false
+	progress := func(complete float64, message string, data interface{}) int {
+		progressCalled = true
+		return 1
+	}
+
+	tests := []struct {
+		name string
+		run  func(result Layer) error
+		area float64
+	}{
+		{"intersection", func(result Layer) error {
+			return input.Intersection(method, result, nil, progress, nil)
+		}, 1},
+		{"union", func(result Layer) error {
+			return input.Union(method, result, nil, nil, nil)
+		}, 7},
+		{"symdifference", func(result Layer) error {
+			return input.SymDifference(method, result, nil, nil, nil)
+		}, 6},
+		{"identity", func(result Layer) error {
+			return input.Identity(method, result, nil, nil, nil)
+		}, 4},
+		{"update", func(result Layer) error {
+			return input.Update(method, result, nil, nil, nil)
+		}, 7},
+		{"clip", func(result Layer) error {
+			return input.Clip(method, result, &LayerAlgebraOptions{PromoteToMulti: true}, nil, nil)
+		}, 1},
+		{"erase", func(result Layer) error {
+			return input.Erase(method, result, &LayerAlgebraOptions{SkipFailures: true}, nil, nil)
+		}, 3},
+	}
+	for _, test := range tests {
+		result := ds.CreateLayer(test.name, SpatialReference{}, GT_Unknown, nil)
+		assert.NoError(t, test.run(result), test.name)
+		assert.InDelta(t, test.area, area(result), 1e-9, test.name)
+	}
+	assert.True(t, progressCalled)
+
+	options := &LayerAlgebraOptions{
+		InputPrefix:                  "in_",
+		MethodPrefix:                 "m_",
+		DropLowerDimensionGeometries: true,
+		Options:                      []string{"PRETEST_CONTAINMENT=YES"},
+	}
+	assert.Equal(t, []string{
+		"INPUT_PREFIX=in_",
+		"METHOD_PREFIX=m_",
+		"KEEP_LOWER_DIMENSION_GEOMETRIES=NO",
+		"PRETEST_CONTAINMENT=YES",
+	}, options.list())
+}
+
+func TestFieldDefinitionConstraints(t *testing.T) {
+	fd := CreateFieldDefinition("flag", FT_Integer)
+	defer fd.Destroy()
+
+	assert.Equal(t, FST_None, fd.SubType())
+	fd.SetSubType(FST_Boolean)
+	assert.Equal(t, FST_Boolean, fd.SubType())
+	assert.Equal(t, "Boolean", FST_Boolean.Name())
+
+	assert.True(t, fd.IsNullable())
+	fd.SetNullable(false)
+	assert.False(t, fd.IsNullable())
+
+	assert.False(t, fd.IsUnique())
+	if VERSION_NUM >= 3020000 {
+		assert.NoError(t, fd.SetUnique(true))
+		assert.True(t, fd.IsUnique())
+	} else {
+		assert.Error(t, fd.SetUnique(true))
+	}
+
+	assert.Equal(t, "", fd.Default())
+	fd.SetDefault("1")
+	assert.Equal(t, "1", fd.Default())
+	assert.False(t, fd.IsDefaultDriverSpecific())
+	fd.SetDefault("")
+	assert.Equal(t, "", fd.Default())
+
+	if VERSION_NUM >= 3070000 {
+		assert.NoError(t, fd.SetComment("set when inspected"))
+		assert.Equal(t, "set when inspected", fd.Comment())
+	} else {
+		assert.Error(t, fd.SetComment("set when inspected"))
+	}
+}
+
+func TestFeatureNullFields(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("flags", SpatialReference{}, GT_None, nil)
+	fd := CreateFieldDefinition("flag", FT_Integer)
+	fd.SetSubType(FST_Boolean)
+	assert.NoError(t, layer.CreateField(fd, true))
+	fd.Destroy()
+
+	feature := layer.Definition().Create()
+	defer feature.Destroy()
+
+	assert.False(t, feature.IsFieldSet(0))
+	assert.False(t, feature.IsFieldNull(0))
+
+	feature.SetFieldBool(0, true)
+	assert.True(t, feature.FieldAsBool(0))
+	assert.Equal(t, 1, feature.FieldAsInteger(0))
+
+	feature.SetFieldNull(0)
+	assert.True(t, feature.IsFieldSet(0))
+	assert.True(t, feature.IsFieldNull(0))
+	assert.False(t, feature.IsFieldSetAndNotNull(0))
+	assert.False(t, feature.FieldAsBool(0))
+
+	feature.UnnsetField(0)
+	assert.False(t, feature.IsFieldSet(0))
+	assert.False(t, feature.IsFieldNull(0))
+}
+
+func TestMultipleGeometryFields(t *testing.T) {
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("assets", SpatialReference{}, GT_Polygon, nil)
+
+	sr := CreateSpatialReference("")
+	sr.FromEPSG(4326)
+	defer sr.Destroy()
+	gfd := CreateGeometryFieldDefinition("centroid", GT_Point)
+	gfd.SetSpatialReference(sr)
+	gfd.SetNullable(false)
+	assert.NoError(t, layer.CreateGeometryField(gfd, true))
+	gfd.Destroy()
+
+	def := layer.Definition()
+	assert.Equal(t, 2, def.GeomFieldCount())
+	assert.Equal(t, 1, def.GeomFieldIndex("centroid"))
+	assert.Equal(t, -1, def.GeomFieldIndex("missing"))
+
+	centroid := def.GeomFieldDefinition(1)
+	assert.Equal(t, "centroid", centroid.Name())
+	assert.Equal(t, GT_Point, centroid.Type())
+	assert.False(t, centroid.IsNullable())
+	assert.False(t, centroid.IsIgnored())
+	assert.True(t, centroid.SpatialReference().IsSame(sr))
+	assert.Equal(t, GT_Polygon, def.GeomFieldDefinition(0).Type())
+
+	feature := def.Create()
+	defer feature.Destroy()
+	assert.Equal(t, 2, feature.GeomFieldCount())
+	assert.Equal(t, "centroid", feature.GeomFieldDefinition(1).Name())
+
+	footprint, _ := CreateFromWKT("POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))", SpatialReference{})
+	assert.NoError(t, feature.SetGeometryFieldDirectly(0, footprint))
+	point := footprint.Centroid()
+	assert.NoError(t, feature.SetGeometryField(feature.GeomFieldIndex("centroid"), point))
+	point.Destroy()
+
+	assert.Equal(t, 4.0, feature.Geometry().Area())
+	assert.Equal(t, 4.0, feature.GeometryField(0).Area())
+	assert.Equal(t, 1.0, feature.GeometryField(1).X(0))
+	assert.True(t, feature.GeometryField(1).SpatialReference().IsSame(sr))
+}
+
+func TestGeometryTypes(t *testing.T) {
+	assert.Equal(t, GeometryType(3017), GT_TriangleZM)
+	assert.True(t, GT_CircularStringZM.HasZ())
+	assert.True(t, GT_CircularStringZM.HasM())
+	assert.False(t, GT_Point25D.HasM())
+	assert.Equal(t, GT_CircularString, GT_CircularStringZM.Flatten())
+	assert.Equal(t, GT_PointZM, GT_Point.SetModifier(true, true))
+	assert.Equal(t, GT_Point25D, GT_PointM.SetModifier(true, false))
+	assert.True(t, GT_CompoundCurve.IsCurve())
+	assert.False(t, GT_Polygon.IsCurve())
+	assert.True(t, GT_CurvePolygon.IsSurface())
+	assert.True(t, GT_MultiCurve.IsNonLinear())
+	assert.True(t, GT_Triangle.IsSubClassOf(GT_Polygon))
+	assert.Equal(t, GT_MultiSurface, GT_CurvePolygon.Collection())
+	assert.Equal(t, GT_CompoundCurve, GT_LineString.Curve())
+	assert.Equal(t, GT_Polygon, GT_CurvePolygon.Linear())
+	assert.Equal(t, "Circular String", GT_CircularString.Name())
+}
+
+func TestCurveGeometry(t *testing.T) {
+	arc, err := CreateFromWKT("CIRCULARSTRING (0 0,1 1,2 0)", SpatialReference{})
+	if err != nil {
+		t.Fatalf("CreateFromWKT: %v", err)
+	}
+	defer arc.Destroy()
+	assert.Equal(t, GT_CircularString, arc.Type())
+	assert.True(t, arc.HasCurveGeometry(true))
+
+	linear := arc.GetLinearGeometry(1, nil)
+	assert.Equal(t, GT_LineString, linear.Type())
+	assert.False(t, linear.HasCurveGeometry(false))
+	assert.True(t, linear.PointCount() > 90)
+
+	curve := linear.GetCurveGeometry(nil)
+	assert.True(t, curve.HasCurveGeometry(true))
+	curve.Destroy()
+
+	multi := linear.ForceTo(GT_MultiCurve, nil)
+	assert.Equal(t, GT_MultiCurve, multi.Type())
+	multi.Destroy()
+}
+
+func TestMeasuredGeometry(t *testing.T) {
+	line := Create(GT_LineString)
+	defer line.Destroy()
+	line.AddPointM(0, 0, 10)
+	line.AddPointM(3, 4, 15)
+	assert.True(t, line.IsMeasured())
+	assert.False(t, line.Is3D())
+	assert.Equal(t, GT_LineStringM, line.Type())
+	assert.Equal(t, 15.0, line.M(1))
+
+	line.SetPointM(1, 6, 8, 20)
+	x, y, _, m := line.PointZM(1)
+	assert.Equal(t, []float64{6, 8, 20}, []float64{x, y, m})
+
+	line.AddPointZM(9, 12, 1, 25)
+	assert.True(t, line.Is3D())
+	assert.Equal(t, GT_LineStringZM, line.Type())
+	line.SetPointZM(0, 0, 0, 2, 5)
+	assert.Equal(t, 5.0, line.M(0))
+
+	wkt, err := line.ToISOWKT()
+	assert.NoError(t, err)
+	assert.Equal(t, "LINESTRING ZM (0 0 2 5,6 8 0 20,9 12 1 25)", wkt)
+
+	wkb, err := line.ToISOWKB()
+	assert.NoError(t, err)
+	parsed, err := CreateFromWKB(wkb, SpatialReference{}, len(wkb))
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, parsed.M(2))
+	parsed.Destroy()
+
+	line.SetMeasured(false)
+	assert.False(t, line.IsMeasured())
+	line.Set3D(false)
+	assert.Equal(t, GT_LineString, line.Type())
+}
+
+func TestGeometryBulkPoints(t *testing.T) {
+	line := Create(GT_LineString)
+	defer line.Destroy()
+	assert.NoError(t, line.SetPoints([]float64{0, 1, 2}, []float64{3, 4, 5}, nil))
+	assert.False(t, line.Is3D())
+
+	xs, ys, zs := line.Points()
+	assert.Equal(t, []float64{0, 1, 2}, xs)
+	assert.Equal(t, []float64{3, 4, 5}, ys)
+	assert.Nil(t, zs)
+
+	assert.NoError(t, line.SetPointsZM([]float64{0, 1}, []float64{2, 3}, []float64{4, 5}, []float64{6, 7}))
+	assert.Equal(t, GT_LineStringZM, line.Type())
+	xs, ys, zs, ms := line.PointsZM()
+	assert.Equal(t, []float64{0, 1}, xs)
+	assert.Equal(t, []float64{2, 3}, ys)
+	assert.Equal(t, []float64{4, 5}, zs)
+	assert.Equal(t, []float64{6, 7}, ms)
+
+	buf := make([]float64, 0, 16)
+	coords := line.PointsInto(buf, LayoutXYM)
+	assert.Equal(t, []float64{0, 2, 6, 1, 3, 7}, coords)
+	assert.Same(t, &buf[:1][0], &coords[0])
+	assert.Equal(t, []float64{0, 2, 4, 6, 1, 3, 5, 7}, line.PointsInto(nil, LayoutXYZM))
+
+	assert.NoError(t, line.SetPointsFrom([]float64{9, 8, 7, 6, 5, 4}, LayoutXYZ))
+	assert.Equal(t, GT_LineString25D, line.Type())
+	x, y, z := line.Point(1)
+	assert.Equal(t, []float64{6, 5, 4}, []float64{x, y, z})
+
+	assert.Error(t, line.SetPoints([]float64{0}, []float64{0, 1}, nil))
+	assert.Error(t, line.SetPointsFrom([]float64{0, 1, 2}, LayoutXY))
+}
+
+func TestGeometryRepair(t *testing.T) {
+	bowtie, _ := CreateFromWKT("POLYGON ((0 0, 2 2, 2 0, 0 2, 0 0))", SpatialReference{})
+	defer bowtie.Destroy()
+	assert.False(t, bowtie.IsValid())
+
+	point := bowtie.PointOnSurface()
+	assert.Equal(t, GT_Point, point.Type())
+	point.Destroy()
+
+	if VERSION_NUM < 3000000 {
+		_, err := bowtie.MakeValid(nil)
+		assert.Error(t, err)
+		return
+	}
+	valid, err := bowtie.MakeValid(nil)
+	if err != nil {
+		t.Skipf("MakeValid unavailable: %v", err)
+	}
+	defer valid.Destroy()
+	assert.True(t, valid.IsValid())
+	assert.InDelta(t, 2.0, valid.Area(), 1e-9)
+
+	if VERSION_NUM >= 3040000 {
+		structure, err := bowtie.MakeValid([]string{"METHOD=STRUCTURE"})
+		if assert.NoError(t, err) {
+			assert.True(t, structure.IsValid())
+			structure.Destroy()
+		}
+	}
+}
+
+func TestGeometryGEOSOperations(t *testing.T) {
+	square, _ := CreateFromWKT("MULTIPOINT ((0 0), (1 0), (1 1), (0 1))", SpatialReference{})
+	defer square.Destroy()
+
+	triangles, err := square.DelaunayTriangulation(0, false)
+	if err != nil {
+		t.Skipf("GEOS unavailable: %v", err)
+	}
+	assert.Equal(t, 2, triangles.GeometryCount())
+	triangles.Destroy()
+
+	if VERSION_NUM >= 3030000 {
+		line, _ := CreateFromWKT("LINESTRING (2 2, 0 0)", SpatialReference{})
+		normal, err := line.Normalize()
+		if assert.NoError(t, err) {
+			wkt, _ := normal.ToWKT()
+			assert.Equal(t, "LINESTRING (0 0,2 2)", wkt)
+			normal.Destroy()
+		}
+		line.Destroy()
+	} else {
+		_, err := square.Normalize()
+		assert.Error(t, err)
+	}
+
+	if VERSION_NUM >= 3060000 && geosAtLeast(3, 11) {
+		hull, err := square.ConcaveHull(1, false)
+		if assert.NoError(t, err) {
+			assert.InDelta(t, 1.0, hull.Area(), 1e-9)
+			hull.Destroy()
+		}
+	} else {
+		_, err := square.ConcaveHull(1, false)
+		assert.Error(t, err)
+	}
+
+	if VERSION_NUM >= 3070000 {
+		polygons, _ := CreateFromWKT("MULTIPOLYGON (((0 0,2 0,2 2,0 2,0 0)),((1 1,3 1,3 3,1 3,1 1)))", SpatialReference{})
+		union, err := polygons.UnaryUnion()
+		if assert.NoError(t, err) {
+			assert.Equal(t, GT_Polygon, union.Type())
+			assert.InDelta(t, 7.0, union.Area(), 1e-9)
+			union.Destroy()
+		}
+		polygons.Destroy()
+	}
+
+	if VERSION_NUM >= 3100000 {
+		line, _ := CreateFromWKT("LINESTRING (0 0, 10 0)", SpatialReference{})
+		buffer, err := line.BufferEx(1, BufferOptions{EndCapStyle: BufferEndCapFlat})
+		if assert.NoError(t, err) {
+			assert.InDelta(t, 20.0, buffer.Area(), 1e-9)
+			buffer.Destroy()
+		}
+		buffer, err = line.BufferEx(1, BufferOptions{EndCapStyle: BufferEndCapFlat, SingleSided: true})
+		if assert.NoError(t, err) {
+			assert.InDelta(t, 10.0, buffer.Area(), 1e-9)
+			buffer.Destroy()
+		}
+		line.Destroy()
+	} else {
+		_, err := square.BufferEx(1, BufferOptions{})
+		assert.Error(t, err)
+	}
+}
+
+func TestPolygonizeWithDangles(t *testing.T) {
+	// a unit square ring, with a chain of two segments hanging off a corner
+	edges, _ := CreateFromWKT(
+		"MULTILINESTRING ((0 0,1 0),(1 0,1 1),(1 1,0 1),(0 1,0 0),(1 1,2 2),(2 2,3 2))",
+		SpatialReference{},
+	)
+	defer edges.Destroy()
+
+	polygons, dangles := edges.PolygonizeWithDangles()
+	defer dangles.Destroy()
+	assert.Equal(t, 2, dangles.GeometryCount())
+	assert.InDelta(t, 1+math.Sqrt2, dangles.Length(), 1e-9)
+	if polygons.IsNull() {
+		t.Skip("GEOS unavailable")
+	}
+	defer polygons.Destroy()
+	assert.Equal(t, 1, polygons.GeometryCount())
+	assert.InDelta(t, 1.0, polygons.Area(), 1e-9)
+}
+
+func wgs84(t *testing.T) SpatialReference {
+	sr := CreateSpatialReference("")
+	if err := sr.FromEPSG(4326); err != nil {
+		t.Fatalf("FromEPSG: %v", err)
+	}
+	sr.SetAxisMappingStrategy(OAMS_TraditionalGisOrder)
+	return sr
+}
+
+func TestGeodesicMeasurements(t *testing.T) {
+	sr := wgs84(t)
+	defer sr.Destroy()
+	square, _ := CreateFromWKT("POLYGON ((0 0,1 0,1 1,0 1,0 0))", sr)
+	defer square.Destroy()
+	line, _ := CreateFromWKT("LINESTRING (0 0,1 0)", sr)
+	defer line.Destroy()
+
+	area, err := square.GeodesicArea()
+	if VERSION_NUM >= 3090000 {
+		assert.NoError(t, err)
+		assert.InEpsilon(t, 1.2309e10, area, 1e-3)
+	} else {
+		assert.Error(t, err)
+	}
+
+	length, err := line.GeodesicLength()
+	if VERSION_NUM >= 3100000 {
+		assert.NoError(t, err)
+		assert.InEpsilon(t, 111319.5, length, 1e-3)
+	} else {
+		assert.Error(t, err)
+	}
+}
+
+func TestGeometryEditing(t *testing.T) {
+	point, _ := CreateFromWKT("POINT (1 2)", SpatialReference{})
+	defer point.Destroy()
+	point.SwapXY()
+	assert.Equal(t, 2.0, point.X(0))
+	assert.Equal(t, 1.0, point.Y(0))
+
+	line, _ := CreateFromWKT("LINESTRING (0.1 0.1,1.9 2.2)", SpatialReference{})
+	defer line.Destroy()
+	snapped, err := line.SetPrecision(1, 0)
+	if VERSION_NUM >= 3090000 {
+		if assert.NoError(t, err) {
+			wkt, _ := snapped.ToWKT()
+			assert.Equal(t, "LINESTRING (0 0,2 2)", wkt)
+			snapped.Destroy()
+		}
+	} else {
+		assert.Error(t, err)
+	}
+}
+
+func TestSegmentizeGeodesic(t *testing.T) {
+	sr := wgs84(t)
+	defer sr.Destroy()
+	line, _ := CreateFromWKT("LINESTRING (0 0,10 0)", sr)
+	defer line.Destroy()
+
+	assert.NoError(t, line.SegmentizeGeodesic(200000))
+	xs, ys, _ := line.Points()
+	assert.Equal(t, 7, len(xs))
+	for i := range xs {
+		assert.InDelta(t, 10*float64(i)/6, xs[i], 1e-9)
+		assert.InDelta(t, 0, ys[i], 1e-9)
+	}
+
+	meridian, _ := CreateFromWKT("LINESTRING (5 0,5 60)", sr)
+	defer meridian.Destroy()
+	assert.NoError(t, meridian.SegmentizeGeodesic(1000000))
+	xs, ys, _ = meridian.Points()
+	assert.Equal(t, 8, len(xs))
+	for _, x := range xs {
+		assert.InDelta(t, 5, x, 1e-9)
+	}
+	// equal lengths of the meridian arc, which are not equal steps of
+	// latitude on the ellipsoid
+	assert.InDelta(t, 8.5961271, ys[1], 1e-7)
+	assert.InDelta(t, 34.3485751, ys[4], 1e-7)
+
+	antipodal, _ := CreateFromWKT("LINESTRING (0 0,179.9 0.5)", sr)
+	defer antipodal.Destroy()
+	assert.Error(t, antipodal.SegmentizeGeodesic(1000000))
+
+	planar, _ := CreateFromWKT("LINESTRING (0 0,10 0)", SpatialReference{})
+	defer planar.Destroy()
+	assert.Error(t, planar.SegmentizeGeodesic(1000))
+}
+
+func TestStyleManager(t *testing.T) {
+	sm := CreateStyleManager(StyleTable{})
+	defer sm.Destroy()
+	assert.NoError(t, sm.InitStyleString("PEN(c:#FF0000,w:2px);BRUSH(fc:#00FF0080)"))
+	assert.Equal(t, 2, sm.PartCount())
+
+	pen := sm.Part(0)
+	if assert.False(t, pen.IsNull()) {
+		assert.Equal(t, STC_Pen, pen.Type())
+		r, g, b, a, ok := pen.ParamColor(STP_PenColor)
+		assert.True(t, ok)
+		assert.Equal(t, []uint8{255, 0, 0, 255}, []uint8{r, g, b, a})
+		pen.SetUnit(STU_Pixel, 1)
+		width, ok := pen.ParamFloat64(STP_PenWidth)
+		assert.True(t, ok)
+		assert.Equal(t, 2.0, width)
+		_, ok = pen.ParamString(STP_PenPattern)
+		assert.False(t, ok)
+		// parameters of other tools, or out of range, do not apply
+		_, ok = pen.ParamString(STP_LabelOColor)
+		assert.False(t, ok)
+		_, ok = pen.ParamFloat64(PenParam(100))
+		assert.False(t, ok)
+		assert.Error(t, pen.SetParamString(STP_LabelOColor, "#000000"))
+		pen.Destroy()
+	}
+
+	brush := sm.Part(1)
+	if assert.False(t, brush.IsNull()) {
+		assert.Equal(t, STC_Brush, brush.Type())
+		_, g, _, a, ok := brush.ParamColor(STP_BrushFColor)
+		assert.True(t, ok)
+		assert.Equal(t, uint8(255), g)
+		assert.Equal(t, uint8(128), a)
+		brush.Destroy()
+	}
+	assert.True(t, sm.Part(2).IsNull())
+
+	label := CreateStyleTool(STC_Label)
+	defer label.Destroy()
+	assert.NoError(t, label.SetParamString(STP_LabelTextString, "Main St"))
+	assert.NoError(t, label.SetParamInt(STP_LabelBold, 1))
+	text, ok := label.ParamString(STP_LabelTextString)
+	assert.True(t, ok)
+	assert.Equal(t, "Main St", text)
+	assert.Contains(t, label.StyleString(), "LABEL(")
+
+	built := CreateStyleManager(StyleTable{})
+	defer built.Destroy()
+	assert.NoError(t, built.AddPart(label))
+	assert.Equal(t, 1, built.PartCount())
+}
+
+func TestStyleTable(t *testing.T) {
+	table := CreateStyleTable()
+	defer table.Destroy()
+	assert.NoError(t, table.AddStyle("red", "PEN(c:#FF0000)"))
+	assert.NoError(t, table.AddStyle("blue", "PEN(c:#0000FF)"))
+
+	style, ok := table.Find("red")
+	assert.True(t, ok)
+	assert.Equal(t, "PEN(c:#FF0000)", style)
+	_, ok = table.Find("green")
+	assert.False(t, ok)
+
+	styles := make(map[string]string)
+	table.ResetStyleStringReading()
+	for {
+		style, ok := table.NextStyle()
+		if !ok {
+			break
+		}
+		styles[table.LastStyleName()] = style
+	}
+	assert.Equal(t, map[string]string{"red": "PEN(c:#FF0000)", "blue": "PEN(c:#0000FF)"}, styles)
+
+	ds := createMemoryDataset(t)
+	defer ds.Close()
+	layer := ds.CreateLayer("styled", SpatialReference{}, GT_Point, nil)
+	assert.True(t, layer.StyleTable().IsNull())
+	layer.SetStyleTable(table)
+	style, ok = layer.StyleTable().Find("blue")
+	assert.True(t, ok)
+	assert.Equal(t, "PEN(c:#0000FF)", style)
+
+	sm := CreateStyleManager(table)
+	defer sm.Destroy()
+	feature := layer.Definition().Create()
+	defer feature.Destroy()
+	feature.SetStyleString("@red")
+	assert.Equal(t, "@red", feature.StyleString())
+	assert.Equal(t, "@red", sm.InitFromFeature(feature))
+	assert.Equal(t, 1, sm.PartCount())
+}
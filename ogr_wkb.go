@@ -0,0 +1,313 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_ogr_wkb.h"
+*/
+import "C"
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+/* -------------------------------------------------------------------- */
+/*      WKB variants, hexadecimal WKB and PostGIS EWKB/EWKT             */
+/* -------------------------------------------------------------------- */
+
+// WKBByteOrder selects the byte order of exported WKB
+type WKBByteOrder int
+
+const (
+	WKB_XDR = WKBByteOrder(C.wkbXDR) // big endian
+	WKB_NDR = WKBByteOrder(C.wkbNDR) // little endian
+)
+
+// WKBVariant selects how exported WKB encodes Z and M values
+type WKBVariant int
+
+const (
+	// Old-style OGC 99-402 extensions, with a 0x80000000 flag for 2.5D
+	// geometries and no M values
+	WKBVariantOldOGC = WKBVariant(C.wkbVariantOldOgc)
+	// ISO SQL/MM, with 1000, 2000 and 3000 added to Z, M and ZM types
+	WKBVariantISO = WKBVariant(C.wkbVariantIso)
+	// PostGIS 1.x, which differs from the old OGC variant for curve types.
+	// Requires GDAL 3.9 or later.
+	WKBVariantPostGIS1 = WKBVariant(C.wkbVariantPostGIS1)
+)
+
+// Convert a geometry to well known binary data with the given byte order
+// and variant
+func (geom Geometry) ToWKBEx(byteOrder WKBByteOrder, variant WKBVariant) ([]uint8, error) {
+	b := make([]uint8, geom.WKBSize())
+	cErr := C.go_ExportToWkbEx(
+		geom.cval,
+		C.OGRwkbByteOrder(byteOrder),
+		C.OGRwkbVariant(variant),
+		(*C.uchar)(unsafe.Pointer(&b[0])),
+	)
+	return b, OGRErrContainer{ErrVal: cErr}.Err()
+}
+
+// Convert a geometry to well known binary data, hexadecimal encoded
+func (geom Geometry) ToHEXWKB() (string, error) {
+	wkb, err := geom.ToWKB()
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(wkb)), nil
+}
+
+// Create a geometry object from its hexadecimal encoded well known binary
+// representation
+func CreateFromHEXWKB(hexWKB string, srs SpatialReference) (Geometry, error) {
+	wkb, err := hex.DecodeString(hexWKB)
+	if err != nil {
+		return Geometry{}, fmt.Errorf("%s: %v", ErrCorruptData, err)
+	}
+	return ParseWKB(wkb, srs)
+}
+
+// Convert a geometry to PostGIS extended well known binary data, tagged
+// with srid unless it is 0
+func (geom Geometry) ToEWKB(srid int) ([]uint8, error) {
+	wkb, err := geom.ToISOWKB()
+	if err != nil {
+		return nil, err
+	}
+	ewkb, _, err := transcodeWKB(wkb, true, srid)
+	return ewkb, err
+}
+
+// Convert a geometry to PostGIS extended well known binary data,
+// hexadecimal encoded as PostGIS outputs it
+func (geom Geometry) ToHEXEWKB(srid int) (string, error) {
+	ewkb, err := geom.ToEWKB(srid)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(ewkb)), nil
+}
+
+// Create a geometry object from its PostGIS extended well known binary
+// representation, also returning its SRID, 0 if it has none.  The SRID is
+// not resolved: srs is assigned to the geometry as is.
+func CreateFromEWKB(ewkb []uint8, srs SpatialReference) (Geometry, int, error) {
+	wkb, srid, err := transcodeWKB(ewkb, false, 0)
+	if err != nil {
+		return Geometry{}, 0, err
+	}
+	geom, err := ParseWKB(wkb, srs)
+	return geom, srid, err
+}
+
+// Create a geometry object from its hexadecimal encoded PostGIS extended
+// well known binary representation, also returning its SRID
+func CreateFromHEXEWKB(hexEWKB string, srs SpatialReference) (Geometry, int, error) {
+	ewkb, err := hex.DecodeString(hexEWKB)
+	if err != nil {
+		return Geometry{}, 0, fmt.Errorf("%s: %v", ErrCorruptData, err)
+	}
+	return CreateFromEWKB(ewkb, srs)
+}
+
+// Fetch geometry as PostGIS extended WKT, prefixed with SRID=srid; unless
+// srid is 0
+func (geom Geometry) ToEWKT(srid int) (string, error) {
+	wkt, err := geom.ToISOWKT()
+	if err != nil || srid == 0 {
+		return wkt, err
+	}
+	return fmt.Sprintf("SRID=%d;%s", srid, wkt), nil
+}
+
+// Create a geometry object from its PostGIS extended WKT representation,
+// also returning its SRID, 0 if it has none
+func CreateFromEWKT(ewkt string, srs SpatialReference) (Geometry, int, error) {
+	srid := 0
+	if strings.HasPrefix(strings.ToUpper(ewkt), "SRID=") {
+		end := strings.IndexByte(ewkt, ';')
+		if end < 0 {
+			return Geometry{}, 0, fmt.Errorf("%s: missing ';' after SRID", ErrCorruptData)
+		}
+		var err error
+		srid, err = strconv.Atoi(strings.TrimSpace(ewkt[len("SRID="):end]))
+		if err != nil {
+			return Geometry{}, 0, fmt.Errorf("%s: invalid SRID: %v", ErrCorruptData, err)
+		}
+		ewkt = ewkt[end+1:]
+	}
+	geom, err := ParseWKT(ewkt, srs)
+	return geom, srid, err
+}
+
+// Copy WKB while rewriting its geometry type codes from ISO to EWKB, or
+// from EWKB or ISO to ISO.  Returns the SRID found or written.
+func transcodeWKB(src []byte, toEWKB bool, srid int) ([]byte, int, error) {
+	t := wkbTranscoder{
+		r:      wkbReader{data: src},
+		out:    make([]byte, 0, len(src)+4),
+		toEWKB: toEWKB,
+		srid:   srid,
+	}
+	if err := t.geometry(true); err != nil {
+		return nil, 0, err
+	}
+	if t.r.pos != len(src) {
+		return nil, 0, fmt.Errorf("%s: trailing bytes after WKB geometry", ErrCorruptData)
+	}
+	return t.out, t.srid, nil
+}
+
+type wkbTranscoder struct {
+	r      wkbReader
+	out    []byte
+	toEWKB bool
+	srid   int
+}
+
+func (t *wkbTranscoder) uint32(v uint32) {
+	var b [4]byte
+	t.r.order.PutUint32(b[:], v)
+	t.out = append(t.out, b[:]...)
+}
+
+// Copy n bytes as is
+func (t *wkbTranscoder) copy(n int) error {
+	if n < 0 || n > len(t.r.data)-t.r.pos {
+		return errTruncatedWKB
+	}
+	t.out = append(t.out, t.r.data[t.r.pos:t.r.pos+n]...)
+	t.r.pos += n
+	return nil
+}
+
+// Copy a count of points followed by the points
+func (t *wkbTranscoder) points(stride int) error {
+	n, err := t.r.uint32()
+	if err != nil {
+		return err
+	}
+	if int(n) > (len(t.r.data)-t.r.pos)/(8*stride) {
+		return errTruncatedWKB
+	}
+	t.uint32(n)
+	return t.copy(int(n) * 8 * stride)
+}
+
+func (t *wkbTranscoder) geometry(top bool) error {
+	r := &t.r
+	if r.pos >= len(r.data) {
+		return errTruncatedWKB
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return fmt.Errorf("%s: invalid WKB byte order", ErrCorruptData)
+	}
+	t.out = append(t.out, r.data[r.pos])
+	r.pos++
+	code, err := r.uint32()
+	if err != nil {
+		return err
+	}
+
+	hasZ := code&0x80000000 != 0
+	hasM := code&0x40000000 != 0
+	if code&0x20000000 != 0 {
+		srid, err := r.uint32()
+		if err != nil {
+			return err
+		}
+		if top {
+			t.srid = int(int32(srid))
+		}
+	}
+	code &= 0x0fffffff
+	switch code / 1000 {
+	case 1:
+		hasZ = true
+	case 2:
+		hasM = true
+	case 3:
+		hasZ, hasM = true, true
+	}
+	base := code % 1000
+
+	if t.toEWKB {
+		ewkbCode := base
+		if hasZ {
+			ewkbCode |= 0x80000000
+		}
+		if hasM {
+			ewkbCode |= 0x40000000
+		}
+		if top && t.srid != 0 {
+			t.uint32(ewkbCode | 0x20000000)
+			t.uint32(uint32(int32(t.srid)))
+		} else {
+			t.uint32(ewkbCode)
+		}
+	} else {
+		isoCode := base
+		if hasZ {
+			isoCode += 1000
+		}
+		if hasM {
+			isoCode += 2000
+		}
+		t.uint32(isoCode)
+	}
+
+	stride := 2
+	if hasZ {
+		stride++
+	}
+	if hasM {
+		stride++
+	}
+	order := r.order
+
+	switch GeometryType(base) {
+	case GT_Point:
+		return t.copy(8 * stride)
+	case GT_LineString, GT_CircularString:
+		return t.points(stride)
+	case GT_Polygon, GT_Triangle:
+		n, err := r.count()
+		if err != nil {
+			return err
+		}
+		t.uint32(uint32(n))
+		for i := 0; i < n; i++ {
+			if err := t.points(stride); err != nil {
+				return err
+			}
+		}
+		return nil
+	case GT_MultiPoint, GT_MultiLineString, GT_MultiPolygon, GT_GeometryCollection,
+		GT_CompoundCurve, GT_CurvePolygon, GT_MultiCurve, GT_MultiSurface,
+		GT_PolyhedralSurface, GT_TIN:
+		n, err := r.count()
+		if err != nil {
+			return err
+		}
+		t.uint32(uint32(n))
+		for i := 0; i < n; i++ {
+			if err := t.geometry(false); err != nil {
+				return err
+			}
+			// parts may have their own byte order
+			r.order = order
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: WKB type %d", ErrUnsupportedGeometryType, code)
+}
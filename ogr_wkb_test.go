@@ -0,0 +1,113 @@
+package gdal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWKBVariants(t *testing.T) {
+	point, _ := CreateFromWKT("POINT (1 2)", SpatialReference{})
+	defer point.Destroy()
+
+	wkb, err := point.ToWKBEx(WKB_XDR, WKBVariantOldOGC)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}, wkb)
+
+	pointZ, _ := CreateFromWKT("POINT Z (1 2 3)", SpatialReference{})
+	defer pointZ.Destroy()
+	wkb, err = pointZ.ToWKBEx(WKB_NDR, WKBVariantISO)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{0xe9, 0x03, 0, 0}, wkb[1:5])
+	wkb, err = pointZ.ToWKBEx(WKB_NDR, WKBVariantOldOGC)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{1, 0, 0, 0x80}, wkb[1:5])
+
+	hexWKB, err := point.ToHEXWKB()
+	assert.NoError(t, err)
+	assert.Equal(t, "0101000000000000000000F03F0000000000000040", hexWKB)
+	parsed, err := CreateFromHEXWKB(hexWKB, SpatialReference{})
+	if assert.NoError(t, err) {
+		assert.True(t, parsed.Equals(point))
+		parsed.Destroy()
+	}
+	_, err = CreateFromHEXWKB("01zz", SpatialReference{})
+	assert.Error(t, err)
+}
+
+func TestEWKB(t *testing.T) {
+	point, _ := CreateFromWKT("POINT (1 2)", SpatialReference{})
+	defer point.Destroy()
+	ewkb, err := point.ToHEXEWKB(4326)
+	assert.NoError(t, err)
+	assert.Equal(t, "0101000020E6100000000000000000F03F0000000000000040", ewkb)
+
+	pointZ, _ := CreateFromWKT("POINT Z (1 2 3)", SpatialReference{})
+	defer pointZ.Destroy()
+	ewkb, err = pointZ.ToHEXEWKB(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "0101000080000000000000F03F00000000000000400000000000000840", ewkb)
+
+	// a multi line string with M values, as PostGIS outputs it
+	multiLineM := "0105000060E610000001000000010200004002000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000040"
+	geom, srid, err := CreateFromHEXEWKB(multiLineM, SpatialReference{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4326, srid)
+		assert.Equal(t, GT_MultiLineStringM, geom.Type())
+		wkt, _ := geom.ToISOWKT()
+		assert.Equal(t, "MULTILINESTRING M ((0 0 0,0 0 2))", wkt)
+
+		ewkb, err := geom.ToHEXEWKB(srid)
+		assert.NoError(t, err)
+		assert.Equal(t, multiLineM, ewkb)
+		geom.Destroy()
+	}
+
+	_, _, err = CreateFromHEXEWKB("0101000020E6100000000000000000F03F", SpatialReference{})
+	assert.Error(t, err)
+}
+
+func TestEWKT(t *testing.T) {
+	geom, srid, err := CreateFromEWKT("SRID=3857;POINT (1 2)", SpatialReference{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3857, srid)
+		ewkt, err := geom.ToEWKT(srid)
+		assert.NoError(t, err)
+		assert.Equal(t, "SRID=3857;POINT (1 2)", ewkt)
+		geom.Destroy()
+	}
+
+	geom, srid, err = CreateFromEWKT("POINT (1 2)", SpatialReference{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, srid)
+		geom.Destroy()
+	}
+
+	_, _, err = CreateFromEWKT("SRID=abc;POINT (1 2)", SpatialReference{})
+	assert.Error(t, err)
+	_, _, err = CreateFromEWKT("SRID=4326;POINT (1", SpatialReference{})
+	assert.Error(t, err)
+}
+
+func TestParsers(t *testing.T) {
+	geom, err := ParseJSON(`{"type": "Point", "coordinates": [1, 2]}`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, GT_Point, geom.Type())
+		geom.Destroy()
+	}
+	_, err = ParseJSON(`{"type": "Point"`)
+	assert.Error(t, err)
+
+	geom, err = ParseGML("<gml:Point><gml:coordinates>1,2</gml:coordinates></gml:Point>")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1.0, geom.X(0))
+		geom.Destroy()
+	}
+	_, err = ParseGML("<gml:Point>")
+	assert.Error(t, err)
+
+	_, err = ParseWKT("POINT (1", SpatialReference{})
+	assert.Error(t, err)
+	_, err = ParseWKB([]uint8{1, 1, 0}, SpatialReference{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+/* ==================================================================== */
+/*      /vsizip/ archive writing                                        */
+/* ==================================================================== */
+
+const vsiZipPrefix = "/vsizip/"
+
+// ZipWriter creates a new zip archive through GDAL's /vsizip/ write
+// support.  Entries are written one at a time: opening a new entry closes
+// the previous one, and the archive is finalized by Close.
+type ZipWriter struct {
+	filename string
+	archive  VSILFILE
+	entry    VSILFILE
+}
+
+// Create a new zip archive.  The filename may be any path understood by
+// the VSI layer (e.g. a local file or a /vsimem/ path), with or without
+// the /vsizip/ prefix.
+func CreateZip(filename string) (*ZipWriter, error) {
+	filename = strings.TrimPrefix(filename, vsiZipPrefix)
+	archive, err := VSIFOpenL(vsiZipPrefix+filename, "wb")
+	if err != nil {
+		return nil, fmt.Errorf("Error: zip archive '%s' create error: %w (%v)", filename, err, CPLGetLastErrorMsg())
+	}
+	return &ZipWriter{filename: filename, archive: archive}, nil
+}
+
+// Start a new entry in the archive and return a writer for its content.
+// The writer is valid until the next call to Create, AddFile or Close.
+func (zw *ZipWriter) Create(name string) (io.Writer, error) {
+	if zw.archive.cval == nil {
+		return nil, fmt.Errorf("Error: zip archive '%s' is closed", zw.filename)
+	}
+	if err := zw.closeEntry(); err != nil {
+		return nil, err
+	}
+	name = strings.TrimPrefix(name, "/")
+	entry, err := VSIFOpenL(vsiZipPrefix+zw.filename+"/"+name, "wb")
+	if err != nil {
+		return nil, fmt.Errorf("Error: zip entry '%s' create error: %w (%v)", name, err, CPLGetLastErrorMsg())
+	}
+	zw.entry = entry
+	return zipEntryWriter{zw}, nil
+}
+
+// Copy the named VSI file into the archive as an entry with the given name
+func (zw *ZipWriter) AddFile(name, srcFilename string) error {
+	src, err := VSIFOpenL(srcFilename, "rb")
+	if err != nil {
+		return err
+	}
+	defer VSIFCloseL(src)
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	buffer := make([]byte, 64*1024)
+	for {
+		n := int(C.VSIFReadL(unsafe.Pointer(&buffer[0]), 1, C.size_t(len(buffer)), src.cval))
+		if n > 0 {
+			if _, err := w.Write(buffer[:n]); err != nil {
+				return err
+			}
+		}
+		if n < len(buffer) {
+			break
+		}
+	}
+	return zw.closeEntry()
+}
+
+// Close the current entry and finalize the archive
+func (zw *ZipWriter) Close() error {
+	if zw.archive.cval == nil {
+		return nil
+	}
+	entryErr := zw.closeEntry()
+	archiveErr := C.VSIFCloseL(zw.archive.cval)
+	zw.archive = VSILFILE{nil}
+	if entryErr != nil {
+		return entryErr
+	}
+	if archiveErr != 0 {
+		return fmt.Errorf("Error: zip archive '%s' close error: %v", zw.filename, CPLGetLastErrorMsg())
+	}
+	return nil
+}
+
+func (zw *ZipWriter) closeEntry() error {
+	if zw.entry.cval == nil {
+		return nil
+	}
+	cErr := C.VSIFCloseL(zw.entry.cval)
+	zw.entry = VSILFILE{nil}
+	if cErr != 0 {
+		return fmt.Errorf("Error: zip entry close error: %v", CPLGetLastErrorMsg())
+	}
+	return nil
+}
+
+type zipEntryWriter struct {
+	zw *ZipWriter
+}
+
+func (w zipEntryWriter) Write(p []byte) (int, error) {
+	if w.zw.entry.cval == nil {
+		return 0, fmt.Errorf("Error: zip entry is closed")
+	}
+	n := VSIFWriteL(p, w.zw.entry)
+	if n != len(p) {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
+
+// Write every file forming the dataset (as reported by FileList) into a
+// new zip archive.  Entries are stored flat, under their base names, so a
+// shapefile produces an archive holding its .shp, .shx, .dbf, .prj, ...
+func ZipDataset(zipFilename string, dataset Dataset) error {
+	return ZipFiles(zipFilename, dataset.FileList())
+}
+
+// Write the named VSI files into a new zip archive, stored flat under
+// their base names
+func ZipFiles(zipFilename string, filenames []string) error {
+	if len(filenames) == 0 {
+		return fmt.Errorf("Error: no files to add to zip archive '%s'", zipFilename)
+	}
+	zw, err := CreateZip(zipFilename)
+	if err != nil {
+		return err
+	}
+	for _, filename := range filenames {
+		if err := zw.AddFile(filepath.Base(filename), filename); err != nil {
+			zw.Close()
+			return err
+		}
+	}
+	return zw.Close()
+}
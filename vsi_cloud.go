@@ -0,0 +1,252 @@
+package gdal
+
+/*
+#include "go_gdal.h"
+#include "go_vsi.h"
+*/
+import "C"
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+)
+
+/* ==================================================================== */
+/*      Cloud storage (/vsis3/, /vsiaz/, /vsigs/, /vsicurl/) settings   */
+/* ==================================================================== */
+
+// VSIConfig is implemented by the typed cloud storage configurations.  It
+// returns the GDAL configuration options the configuration translates to.
+type VSIConfig interface {
+	ConfigOptions() map[string]string
+}
+
+// S3Config holds the settings used by /vsis3/, including for S3-compatible
+// servers such as MinIO.
+type S3Config struct {
+	// Endpoint is the host (and optional port) of the server, without
+	// scheme.  Maps to AWS_S3_ENDPOINT.
+	Endpoint string
+	// Region maps to AWS_REGION
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Profile selects a profile of the AWS credentials file
+	Profile string
+	// PathStyle requests path-style addressing (http://endpoint/bucket/key)
+	// instead of virtual hosting.  Maps to AWS_VIRTUAL_HOSTING=FALSE.
+	PathStyle bool
+	// DisableHTTPS uses plain HTTP to reach the endpoint.  Maps to
+	// AWS_HTTPS=NO.
+	DisableHTTPS bool
+	// NoSignRequest disables request signing, for public buckets
+	NoSignRequest bool
+}
+
+// Fetch the configuration options for these settings
+func (cfg S3Config) ConfigOptions() map[string]string {
+	options := make(map[string]string)
+	setIfNotEmpty(options, "AWS_S3_ENDPOINT", cfg.Endpoint)
+	setIfNotEmpty(options, "AWS_REGION", cfg.Region)
+	setIfNotEmpty(options, "AWS_ACCESS_KEY_ID", cfg.AccessKeyID)
+	setIfNotEmpty(options, "AWS_SECRET_ACCESS_KEY", cfg.SecretAccessKey)
+	setIfNotEmpty(options, "AWS_SESSION_TOKEN", cfg.SessionToken)
+	setIfNotEmpty(options, "AWS_PROFILE", cfg.Profile)
+	if cfg.PathStyle {
+		options["AWS_VIRTUAL_HOSTING"] = "FALSE"
+	}
+	if cfg.DisableHTTPS {
+		options["AWS_HTTPS"] = "NO"
+	}
+	if cfg.NoSignRequest {
+		options["AWS_NO_SIGN_REQUEST"] = "YES"
+	}
+	return options
+}
+
+// AzureConfig holds the settings used by /vsiaz/
+type AzureConfig struct {
+	// ConnectionString maps to AZURE_STORAGE_CONNECTION_STRING.  It may
+	// carry a BlobEndpoint, which is how a local emulator is addressed.
+	ConnectionString string
+	StorageAccount   string
+	AccessKey        string
+	SASToken         string
+	NoSignRequest    bool
+}
+
+// Fetch the configuration options for these settings
+func (cfg AzureConfig) ConfigOptions() map[string]string {
+	options := make(map[string]string)
+	setIfNotEmpty(options, "AZURE_STORAGE_CONNECTION_STRING", cfg.ConnectionString)
+	setIfNotEmpty(options, "AZURE_STORAGE_ACCOUNT", cfg.StorageAccount)
+	setIfNotEmpty(options, "AZURE_STORAGE_ACCESS_KEY", cfg.AccessKey)
+	setIfNotEmpty(options, "AZURE_STORAGE_SAS_TOKEN", cfg.SASToken)
+	if cfg.NoSignRequest {
+		options["AZURE_NO_SIGN_REQUEST"] = "YES"
+	}
+	return options
+}
+
+// GSConfig holds the settings used by /vsigs/
+type GSConfig struct {
+	// Endpoint is the root URL of the server.  Maps to CPL_GS_ENDPOINT.
+	Endpoint           string
+	AccessKeyID        string
+	SecretAccessKey    string
+	OAuth2RefreshToken string
+	// ApplicationCredentials is the path of a service account JSON file
+	ApplicationCredentials string
+	NoSignRequest          bool
+}
+
+// Fetch the configuration options for these settings
+func (cfg GSConfig) ConfigOptions() map[string]string {
+	options := make(map[string]string)
+	setIfNotEmpty(options, "CPL_GS_ENDPOINT", cfg.Endpoint)
+	setIfNotEmpty(options, "GS_ACCESS_KEY_ID", cfg.AccessKeyID)
+	setIfNotEmpty(options, "GS_SECRET_ACCESS_KEY", cfg.SecretAccessKey)
+	setIfNotEmpty(options, "GS_OAUTH2_REFRESH_TOKEN", cfg.OAuth2RefreshToken)
+	setIfNotEmpty(options, "GOOGLE_APPLICATION_CREDENTIALS", cfg.ApplicationCredentials)
+	if cfg.NoSignRequest {
+		options["GS_NO_SIGN_REQUEST"] = "YES"
+	}
+	return options
+}
+
+// CurlConfig holds the HTTP settings used by /vsicurl/ (and shared by the
+// other network file systems)
+type CurlConfig struct {
+	// UserPwd is a "user:password" pair.  Maps to GDAL_HTTP_USERPWD.
+	UserPwd string
+	// Auth is the authentication scheme (BASIC, NTLM, ...)
+	Auth string
+	// Headers holds extra request headers, as "Name: value" lines
+	Headers string
+	Proxy   string
+	// Timeout is the request timeout in seconds, or 0 for the default
+	Timeout int
+	// UnsafeSSL disables certificate verification
+	UnsafeSSL bool
+}
+
+// Fetch the configuration options for these settings
+func (cfg CurlConfig) ConfigOptions() map[string]string {
+	options := make(map[string]string)
+	setIfNotEmpty(options, "GDAL_HTTP_USERPWD", cfg.UserPwd)
+	setIfNotEmpty(options, "GDAL_HTTP_AUTH", cfg.Auth)
+	setIfNotEmpty(options, "GDAL_HTTP_HEADERS", cfg.Headers)
+	setIfNotEmpty(options, "GDAL_HTTP_PROXY", cfg.Proxy)
+	if cfg.Timeout > 0 {
+		options["GDAL_HTTP_TIMEOUT"] = fmt.Sprint(cfg.Timeout)
+	}
+	if cfg.UnsafeSSL {
+		options["GDAL_HTTP_UNSAFESSL"] = "YES"
+	}
+	return options
+}
+
+func setIfNotEmpty(options map[string]string, key, value string) {
+	if value != "" {
+		options[key] = value
+	}
+}
+
+// sortedKeys returns the keys of a configuration map in a stable order
+func sortedKeys(options map[string]string) []string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set a configuration option for all files under the given path prefix,
+// e.g. "/vsis3/my-bucket".  Requires GDAL 3.5 or later.
+func VSISetPathSpecificOption(pathPrefix, key, value string) error {
+	cPrefix := C.CString(pathPrefix)
+	defer C.free(unsafe.Pointer(cPrefix))
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+
+	if C.go_VSISetPathSpecificOption(cPrefix, cKey, cValue) == 0 {
+		return fmt.Errorf("%s: path specific options require GDAL 3.5 or later", ErrUnsupportedOperation)
+	}
+	return nil
+}
+
+// Clear the path specific options set for the given prefix, or all of them
+// if the prefix is empty.  Requires GDAL 3.5 or later.
+func VSIClearPathSpecificOptions(pathPrefix string) error {
+	var cPrefix *C.char
+	if pathPrefix != "" {
+		cPrefix = C.CString(pathPrefix)
+		defer C.free(unsafe.Pointer(cPrefix))
+	}
+	if C.go_VSIClearPathSpecificOptions(cPrefix) == 0 {
+		return fmt.Errorf("%s: path specific options require GDAL 3.5 or later", ErrUnsupportedOperation)
+	}
+	return nil
+}
+
+// Apply a cloud storage configuration to all files under the given path
+// prefix.  This lets several buckets, each with their own endpoint and
+// credentials, be used from the same process.
+func SetPathSpecificConfig(pathPrefix string, cfg VSIConfig) error {
+	options := cfg.ConfigOptions()
+	for _, key := range sortedKeys(options) {
+		if err := VSISetPathSpecificOption(pathPrefix, key, options[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply a cloud storage configuration to the calling OS thread.  See
+// CPLSetThreadLocalConfigOption.
+func SetThreadLocalConfig(cfg VSIConfig) {
+	options := cfg.ConfigOptions()
+	for _, key := range sortedKeys(options) {
+		CPLSetThreadLocalConfigOption(key, options[key])
+	}
+}
+
+// Clean the cache of file states and content of the network file systems
+func VSICurlClearCache() {
+	C.VSICurlClearCache()
+}
+
+// Clean the cache of file states and content for files under the given
+// prefix, e.g. "/vsis3/my-bucket"
+func VSICurlPartialClearCache(pathPrefix string) {
+	cPrefix := C.CString(pathPrefix)
+	defer C.free(unsafe.Pointer(cPrefix))
+	C.VSICurlPartialClearCache(cPrefix)
+}
+
+// Return a signed URL for a /vsis3/, /vsigs/, /vsiaz/ ... file that can be
+// used without credentials.  Options are KEY=VALUE pairs such as
+// EXPIRATION_DELAY=3600 or VERB=PUT.
+func VSIGetSignedURL(filename string, options []string) (string, error) {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	length := len(options)
+	opts := make([]*C.char, length+1)
+	for i := 0; i < length; i++ {
+		opts[i] = C.CString(options[i])
+		defer C.free(unsafe.Pointer(opts[i]))
+	}
+	opts[length] = (*C.char)(unsafe.Pointer(nil))
+
+	url := C.VSIGetSignedURL(cFilename, (**C.char)(unsafe.Pointer(&opts[0])))
+	if url == nil {
+		return "", fmt.Errorf("Error: no signed URL for '%s': %v", filename, CPLGetLastErrorMsg())
+	}
+	defer C.CPLFree(unsafe.Pointer(url))
+	return C.GoString(url), nil
+}
@@ -0,0 +1,110 @@
+package gdal
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3ConfigOptions(t *testing.T) {
+	cfg := S3Config{
+		Endpoint:        "localhost:9000",
+		Region:          "us-east-1",
+		AccessKeyID:     "minioadmin",
+		SecretAccessKey: "minioadmin",
+		PathStyle:       true,
+		DisableHTTPS:    true,
+	}
+	assert.Equal(t, map[string]string{
+		"AWS_S3_ENDPOINT":       "localhost:9000",
+		"AWS_REGION":            "us-east-1",
+		"AWS_ACCESS_KEY_ID":     "minioadmin",
+		"AWS_SECRET_ACCESS_KEY": "minioadmin",
+		"AWS_VIRTUAL_HOSTING":   "FALSE",
+		"AWS_HTTPS":             "NO",
+	}, cfg.ConfigOptions())
+}
+
+func TestS3SignedURLPerBucket(t *testing.T) {
+	if VERSION_NUM < 3060000 {
+		t.Skip("path specific options require GDAL 3.6")
+	}
+	defer VSIClearPathSpecificOptions("")
+
+	err := SetPathSpecificConfig("/vsis3/bucket-a", S3Config{
+		Endpoint:        "localhost:9000",
+		Region:          "us-east-1",
+		AccessKeyID:     "tenant-a",
+		SecretAccessKey: "secret-a",
+		PathStyle:       true,
+		DisableHTTPS:    true,
+	})
+	assert.NoError(t, err)
+	err = SetPathSpecificConfig("/vsis3/bucket-b", S3Config{
+		Endpoint:        "localhost:9001",
+		Region:          "us-east-1",
+		AccessKeyID:     "tenant-b",
+		SecretAccessKey: "secret-b",
+		PathStyle:       true,
+		DisableHTTPS:    true,
+	})
+	assert.NoError(t, err)
+
+	urlA, err := VSIGetSignedURL("/vsis3/bucket-a/key.tif", nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(urlA, "http://localhost:9000/bucket-a/key.tif?"), urlA)
+	assert.Contains(t, urlA, "tenant-a")
+
+	urlB, err := VSIGetSignedURL("/vsis3/bucket-b/key.tif", nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(urlB, "http://localhost:9001/bucket-b/key.tif?"), urlB)
+	assert.Contains(t, urlB, "tenant-b")
+}
+
+// TestS3Server runs against a live S3-compatible server (e.g. MinIO) when
+// GDAL_TEST_S3_ENDPOINT, GDAL_TEST_S3_BUCKET, GDAL_TEST_S3_ACCESS_KEY_ID
+// and GDAL_TEST_S3_SECRET_ACCESS_KEY are set.
+func TestS3Server(t *testing.T) {
+	endpoint := os.Getenv("GDAL_TEST_S3_ENDPOINT")
+	bucket := os.Getenv("GDAL_TEST_S3_BUCKET")
+	if endpoint == "" || bucket == "" {
+		t.Skip("GDAL_TEST_S3_ENDPOINT and GDAL_TEST_S3_BUCKET not set")
+	}
+	if VERSION_NUM < 3060000 {
+		t.Skip("path specific options require GDAL 3.6")
+	}
+	prefix := "/vsis3/" + bucket
+	defer VSIClearPathSpecificOptions(prefix)
+	defer VSICurlPartialClearCache(prefix)
+
+	err := SetPathSpecificConfig(prefix, S3Config{
+		Endpoint:        endpoint,
+		Region:          "us-east-1",
+		AccessKeyID:     os.Getenv("GDAL_TEST_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("GDAL_TEST_S3_SECRET_ACCESS_KEY"),
+		PathStyle:       true,
+		DisableHTTPS:    true,
+	})
+	if err != nil {
+		t.Fatalf("SetPathSpecificConfig: %v", err)
+	}
+
+	filename := prefix + "/gdal-go-test.txt"
+	f, err := VSIFOpenL(filename, "wb")
+	if err != nil {
+		t.Fatalf("VSIFOpenL: %v", err)
+	}
+	VSIFWriteL([]byte("hello"), f)
+	VSIFCloseL(f)
+	defer VSIUnlink(filename)
+
+	VSICurlClearCache()
+	f, err = VSIFOpenL(filename, "rb")
+	if err != nil {
+		t.Fatalf("VSIFOpenL: %v", err)
+	}
+	defer VSIFCloseL(f)
+	assert.Equal(t, "hello", string(VSIFReadL(1, 5, f)))
+}
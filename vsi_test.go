@@ -0,0 +1,64 @@
+package gdal
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZipDataset(t *testing.T) {
+	ds, err := OpenEx("testdata/test.shp", OFReadOnly|OFVector, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to open test file: %v", err)
+	}
+	defer ds.Close()
+
+	const zipName = "/vsimem/test_shp.zip"
+	defer VSIUnlink(zipName)
+
+	if err := ZipDataset(zipName, ds); err != nil {
+		t.Fatalf("ZipDataset: %v", err)
+	}
+
+	entries := VSIReadDirRecursive("/vsizip/" + zipName)
+	sort.Strings(entries)
+	assert.Equal(t, []string{"test.dbf", "test.prj", "test.shp", "test.shx"}, entries)
+
+	zipped, err := OpenEx("/vsizip/"+zipName+"/test.shp", OFReadOnly|OFVector, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to open zipped shapefile: %v", err)
+	}
+	defer zipped.Close()
+	assert.Equal(t, "test", zipped.LayerByIndex(0).Name())
+}
+
+func TestZipWriterEntries(t *testing.T) {
+	const zipName = "/vsimem/entries.zip"
+	defer VSIUnlink(zipName)
+
+	zw, err := CreateZip(zipName)
+	if err != nil {
+		t.Fatalf("CreateZip: %v", err)
+	}
+	w, err := zw.Create("a.txt")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	w.Write([]byte("hello"))
+	w, err = zw.Create("dir/b.txt")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	w.Write([]byte("world"))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := VSIFOpenL("/vsizip/"+zipName+"/dir/b.txt", "rb")
+	if err != nil {
+		t.Fatalf("VSIFOpenL: %v", err)
+	}
+	defer VSIFCloseL(f)
+	assert.Equal(t, "world", string(VSIFReadL(1, 5, f)))
+}